@@ -0,0 +1,242 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// Builder constructs a TypeDecl, e.g. for RegisterTypes, without having
+// to write out its nested ast nodes by hand:
+//
+//	compiler.NewObject("User").
+//		Implements("Node").
+//		Field("id", compiler.NonNull("ID")).
+//		Field("friends", compiler.List("User"), compiler.Arg("first", "Int")).
+//		Decl()
+//
+// Types are given as either a type name, or one of: *ast.Ident, *ast.List
+// and *ast.NonNull, e.g. as returned by Named, List, NonNull or ParseType.
+//
+type Builder struct {
+	decl *ast.TypeDecl
+	ts   *ast.TypeSpec
+}
+
+func newBuilder(tok token.Token, name string, typ interface{}) *Builder {
+	ts := &ast.TypeSpec{Name: &ast.Ident{Name: name}}
+	switch v := typ.(type) {
+	case *ast.ScalarType:
+		v.Name = ts.Name
+		ts.Type = &ast.TypeSpec_Scalar{Scalar: v}
+	case *ast.ObjectType:
+		ts.Type = &ast.TypeSpec_Object{Object: v}
+	case *ast.InterfaceType:
+		ts.Type = &ast.TypeSpec_Interface{Interface: v}
+	case *ast.UnionType:
+		ts.Type = &ast.TypeSpec_Union{Union: v}
+	case *ast.EnumType:
+		ts.Type = &ast.TypeSpec_Enum{Enum: v}
+	case *ast.InputType:
+		ts.Type = &ast.TypeSpec_Input{Input: v}
+	case *ast.DirectiveType:
+		ts.Type = &ast.TypeSpec_Directive{Directive: v}
+	}
+
+	return &Builder{
+		decl: &ast.TypeDecl{Tok: tok, Spec: &ast.TypeDecl_TypeSpec{TypeSpec: ts}},
+		ts:   ts,
+	}
+}
+
+// NewScalar returns a Builder for a scalar type.
+func NewScalar(name string) *Builder {
+	return newBuilder(token.Token_SCALAR, name, &ast.ScalarType{})
+}
+
+// NewObject returns a Builder for an object type.
+func NewObject(name string) *Builder {
+	return newBuilder(token.Token_TYPE, name, &ast.ObjectType{})
+}
+
+// NewInterface returns a Builder for an interface type.
+func NewInterface(name string) *Builder {
+	return newBuilder(token.Token_INTERFACE, name, &ast.InterfaceType{})
+}
+
+// NewUnion returns a Builder for a union type of the given members.
+func NewUnion(name string, members ...string) *Builder {
+	return newBuilder(token.Token_UNION, name, &ast.UnionType{}).Implements(members...)
+}
+
+// NewEnum returns a Builder for an enum type of the given values.
+func NewEnum(name string, values ...string) *Builder {
+	return newBuilder(token.Token_ENUM, name, &ast.EnumType{}).Value(values...)
+}
+
+// NewInput returns a Builder for an input object type.
+func NewInput(name string) *Builder {
+	return newBuilder(token.Token_INPUT, name, &ast.InputType{})
+}
+
+// NewDirective returns a Builder for a directive applicable at the given locations.
+func NewDirective(name string, locs ...ast.DirectiveLocation_Loc) *Builder {
+	dir := &ast.DirectiveType{}
+	for _, loc := range locs {
+		dir.Locs = append(dir.Locs, &ast.DirectiveLocation{Loc: loc})
+	}
+	return newBuilder(token.Token_DIRECTIVE, name, dir)
+}
+
+// Field adds a field to an object or interface type, an input field to an
+// input object, or an argument to a directive. Arguments are only allowed
+// for object and interface fields.
+//
+func (b *Builder) Field(name string, typ interface{}, args ...*ast.InputValue) *Builder {
+	var fields **ast.FieldList
+	var inputs **ast.InputValueList
+	switch v := b.ts.Type.(type) {
+	case *ast.TypeSpec_Object:
+		fields = &v.Object.Fields
+	case *ast.TypeSpec_Interface:
+		fields = &v.Interface.Fields
+	case *ast.TypeSpec_Input:
+		inputs = &v.Input.Fields
+	case *ast.TypeSpec_Directive:
+		inputs = &v.Directive.Args
+	default:
+		panic(fmt.Sprintf("compiler: %s can not have fields", b.decl.Tok))
+	}
+
+	if inputs != nil {
+		if len(args) > 0 {
+			panic(fmt.Sprintf("compiler: %s fields can not have arguments", b.decl.Tok))
+		}
+		if *inputs == nil {
+			*inputs = &ast.InputValueList{}
+		}
+		(*inputs).List = append((*inputs).List, Arg(name, typ))
+		return b
+	}
+
+	f := &ast.Field{Name: &ast.Ident{Name: name}}
+	switch v := typeOf(typ).(type) {
+	case *ast.Ident:
+		f.Type = &ast.Field_Ident{Ident: v}
+	case *ast.List:
+		f.Type = &ast.Field_List{List: v}
+	case *ast.NonNull:
+		f.Type = &ast.Field_NonNull{NonNull: v}
+	}
+	if len(args) > 0 {
+		f.Args = &ast.InputValueList{List: args}
+	}
+
+	if *fields == nil {
+		*fields = &ast.FieldList{}
+	}
+	(*fields).List = append((*fields).List, f)
+	return b
+}
+
+// Value adds values to an enum type.
+func (b *Builder) Value(names ...string) *Builder {
+	v, ok := b.ts.Type.(*ast.TypeSpec_Enum)
+	if !ok {
+		panic(fmt.Sprintf("compiler: %s can not have values", b.decl.Tok))
+	}
+
+	if v.Enum.Values == nil {
+		v.Enum.Values = &ast.FieldList{}
+	}
+	for _, name := range names {
+		v.Enum.Values.List = append(v.Enum.Values.List, &ast.Field{Name: &ast.Ident{Name: name}})
+	}
+	return b
+}
+
+// Implements adds interfaces to an object type, or members to a union type.
+func (b *Builder) Implements(names ...string) *Builder {
+	var ids *[]*ast.Ident
+	switch v := b.ts.Type.(type) {
+	case *ast.TypeSpec_Object:
+		ids = &v.Object.Interfaces
+	case *ast.TypeSpec_Union:
+		ids = &v.Union.Members
+	default:
+		panic(fmt.Sprintf("compiler: %s can not implement types", b.decl.Tok))
+	}
+
+	for _, name := range names {
+		*ids = append(*ids, &ast.Ident{Name: name})
+	}
+	return b
+}
+
+// Directive applies a directive to the type.
+func (b *Builder) Directive(name string, args ...*ast.Arg) *Builder {
+	d := &ast.DirectiveLit{Name: name}
+	if len(args) > 0 {
+		d.Args = &ast.CallExpr{Args: args}
+	}
+
+	b.ts.Directives = append(b.ts.Directives, d)
+	return b
+}
+
+// Decl returns the constructed TypeDecl.
+func (b *Builder) Decl() *ast.TypeDecl { return b.decl }
+
+// Arg returns an argument, or input field, definition.
+func Arg(name string, typ interface{}) *ast.InputValue {
+	v := &ast.InputValue{Name: &ast.Ident{Name: name}}
+	switch t := typeOf(typ).(type) {
+	case *ast.Ident:
+		v.Type = &ast.InputValue_Ident{Ident: t}
+	case *ast.List:
+		v.Type = &ast.InputValue_List{List: t}
+	case *ast.NonNull:
+		v.Type = &ast.InputValue_NonNull{NonNull: t}
+	}
+	return v
+}
+
+// Named returns a reference to the named type.
+func Named(name string) *ast.Ident { return &ast.Ident{Name: name} }
+
+// List returns a list of the given type.
+func List(typ interface{}) *ast.List {
+	l := &ast.List{}
+	switch v := typeOf(typ).(type) {
+	case *ast.Ident:
+		l.Type = &ast.List_Ident{Ident: v}
+	case *ast.List:
+		l.Type = &ast.List_List{List: v}
+	case *ast.NonNull:
+		l.Type = &ast.List_NonNull{NonNull: v}
+	}
+	return l
+}
+
+// NonNull returns a non-null version of the given type.
+func NonNull(typ interface{}) *ast.NonNull {
+	n := &ast.NonNull{}
+	switch v := typeOf(typ).(type) {
+	case *ast.Ident:
+		n.Type = &ast.NonNull_Ident{Ident: v}
+	case *ast.List:
+		n.Type = &ast.NonNull_List{List: v}
+	case *ast.NonNull:
+		panic("compiler: type is already non-null: " + TypeString(v))
+	}
+	return n
+}
+
+// typeOf converts a type name into a type reference.
+func typeOf(typ interface{}) interface{} {
+	if name, ok := typ.(string); ok {
+		return Named(name)
+	}
+	return typ
+}
@@ -0,0 +1,71 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestBuilder(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`scalar Time @a
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	friends(first: Int, after: [String!]): [User!]!
+}
+
+union Result = User | Page
+
+enum Role {
+	USER
+	ADMIN
+}
+
+input Filter {
+	role: Role
+	ids: [ID!]
+}
+
+directive @a(b: String!) on SCALAR | OBJECT`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	built := &ast.Document{Name: "test"}
+	built.Types = []*ast.TypeDecl{
+		NewScalar("Time").Directive("a").Decl(),
+		NewInterface("Node").Field("id", NonNull("ID")).Decl(),
+		NewObject("User").
+			Implements("Node").
+			Field("id", NonNull("ID")).
+			Field("friends", NonNull(List(NonNull("User"))), Arg("first", "Int"), Arg("after", List(NonNull("String")))).
+			Decl(),
+		NewUnion("Result", "User", "Page").Decl(),
+		NewEnum("Role", "USER", "ADMIN").Decl(),
+		NewInput("Filter").Field("role", "Role").Field("ids", List(NonNull(Named("ID")))).Decl(),
+		NewDirective("a", ast.DirectiveLocation_SCALAR, ast.DirectiveLocation_OBJECT).Field("b", NonNull("String")).Decl(),
+	}
+
+	expected := SchemaHash(ToIR([]*ast.Document{doc}))
+	if h := SchemaHash(ToIR([]*ast.Document{built})); h != expected {
+		t.Errorf("expected built types to match SDL: %s != %s", h, expected)
+	}
+
+	t.Run("InvalidField", func(subT *testing.T) {
+		defer func() {
+			if recover() == nil {
+				subT.Error("expected panic when adding a field to a scalar")
+			}
+		}()
+
+		NewScalar("Time").Field("a", "Int")
+	})
+}
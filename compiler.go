@@ -22,14 +22,49 @@ func Lookup(name string, ir IR) (*ast.Document, []*ast.TypeDecl) {
 	return nil, nil
 }
 
+// Documents returns the Documents in the IR sorted by name.
+func (ir IR) Documents() []*ast.Document {
+	docs := make([]*ast.Document, 0, len(ir))
+	for doc := range ir {
+		docs = append(docs, doc)
+	}
+
+	sort.Slice(docs, func(i, j int) bool { return docs[i].Name < docs[j].Name })
+	return docs
+}
+
+// TypeNames returns the names of the given types sorted by
+// the position of their first declaration, and then by name.
+//
+func TypeNames(types map[string][]*ast.TypeDecl) []string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		a, b := types[names[i]], types[names[j]]
+		if len(a) > 0 && len(b) > 0 && a[0].TokPos != b[0].TokPos {
+			return a[0].TokPos < b[0].TokPos
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
 // ToIR converts a GraphQL Document to a intermediate
 // representation for the compiler internals.
 //
+// Any descriptions preceding the description of a Document's first type
+// declaration are moved to the Document, see DocDescription.
+//
 func ToIR(docs []*ast.Document) IR {
 	ir := make(map[*ast.Document]map[string][]*ast.TypeDecl, len(docs))
 
 	var ts *ast.TypeSpec
 	for _, doc := range docs {
+		liftDocDescription(doc)
+
 		types := make(map[string][]*ast.TypeDecl, len(doc.Types))
 		ir[doc] = types
 
@@ -72,7 +107,32 @@ func FromIR(ir IR) []*ast.Document {
 			doc.Types = append(doc.Types, decls...)
 		}
 
-		sort.Sort(byTypeAndName{types: &doc.Types})
+		sort.Stable(byTypeAndName{types: &doc.Types})
+	}
+
+	return docs
+}
+
+// FromIRInOrder is the same as FromIR, but keeps the types of each
+// Document, including any extensions, in their original declaration
+// order, instead of sorting them by kind and name. Declarations added
+// to the IR, which have no position, are kept after the others.
+//
+func FromIRInOrder(ir IR) []*ast.Document {
+	docs := ir.Documents()
+
+	for _, doc := range docs {
+		mdecls := ir[doc]
+		doc.Types = doc.Types[:0]
+
+		for _, name := range TypeNames(mdecls) {
+			doc.Types = append(doc.Types, mdecls[name]...)
+		}
+
+		sort.SliceStable(doc.Types, func(i, j int) bool {
+			a, b := doc.Types[i].TokPos, doc.Types[j].TokPos
+			return a != 0 && (b == 0 || a < b)
+		})
 	}
 
 	return docs
@@ -95,57 +155,42 @@ const (
 	directives
 )
 
+// Less orders declarations by kind and then by name. Extensions, e.g.
+// those which couldn't be merged, are ordered by the kind they extend,
+// after the definition of the type.
+//
 func (s byTypeAndName) Less(i, j int) bool {
 	is := (*s.types)[i]
 	js := (*s.types)[j]
 
-	its := is.Spec.(*ast.TypeDecl_TypeSpec).TypeSpec
-	jts := js.Spec.(*ast.TypeDecl_TypeSpec).TypeSpec
-
-	if is.Tok == js.Tok {
-		return its.Name.Name < jts.Name.Name
+	if iOrd, jOrd := tokOrd(DeclTok(is)), tokOrd(DeclTok(js)); iOrd != jOrd {
+		return iOrd < jOrd
 	}
 
-	var iOrd, jOrd ord
-	switch is.Tok {
-	case token.Token_SCHEMA:
-		iOrd = schema
-	case token.Token_SCALAR:
-		iOrd = scalars
-	case token.Token_TYPE:
-		iOrd = objects
-	case token.Token_INTERFACE:
-		iOrd = interfaces
-	case token.Token_UNION:
-		iOrd = unions
-	case token.Token_ENUM:
-		iOrd = enums
-	case token.Token_INPUT:
-		iOrd = inputs
-	case token.Token_DIRECTIVE:
-		iOrd = directives
+	if iName, jName := declName(is), declName(js); iName != jName {
+		return iName < jName
 	}
+	return !IsExtension(is) && IsExtension(js)
+}
 
-	switch js.Tok {
-	case token.Token_SCHEMA:
-		jOrd = schema
+func tokOrd(tok token.Token) ord {
+	switch tok {
 	case token.Token_SCALAR:
-		jOrd = scalars
+		return scalars
 	case token.Token_TYPE:
-		jOrd = objects
+		return objects
 	case token.Token_INTERFACE:
-		jOrd = interfaces
+		return interfaces
 	case token.Token_UNION:
-		jOrd = unions
+		return unions
 	case token.Token_ENUM:
-		jOrd = enums
+		return enums
 	case token.Token_INPUT:
-		jOrd = inputs
+		return inputs
 	case token.Token_DIRECTIVE:
-		jOrd = directives
+		return directives
 	}
-
-	return iOrd < jOrd
+	return schema
 }
 
 func (s byTypeAndName) Swap(i, j int) {
@@ -0,0 +1,56 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestFromIRInOrder(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`type Query {
+	a: A
+}
+
+scalar A
+
+extend type Query {
+	b: B
+}
+
+enum B {
+	X
+	Y
+}`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ir := ToIR([]*ast.Document{doc})
+
+	added := &ast.TypeDecl{
+		Tok: token.Token_SCALAR,
+		Spec: &ast.TypeDecl_TypeSpec{TypeSpec: &ast.TypeSpec{
+			Name: &ast.Ident{Name: "Added"},
+			Type: &ast.TypeSpec_Scalar{Scalar: &ast.ScalarType{Name: &ast.Ident{Name: "Added"}}},
+		}},
+	}
+	ir[doc]["Added"] = []*ast.TypeDecl{added}
+
+	docs := FromIRInOrder(ir)
+	if len(docs) != 1 {
+		t.Fatalf("expected one document but got: %d", len(docs))
+	}
+
+	var order []string
+	for _, decl := range docs[0].Types {
+		order = append(order, DeclTok(decl).String()+" "+declName(decl))
+	}
+
+	expected := []string{"TYPE Query", "SCALAR A", "TYPE Query", "ENUM B", "SCALAR Added"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected order: %v but got: %v", expected, order)
+	}
+}
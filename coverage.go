@@ -0,0 +1,191 @@
+package compiler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// CoverageCount counts how many members of a schema have a description.
+type CoverageCount struct {
+	Described int
+	Total     int
+}
+
+// Missing returns the number of members without a description.
+func (c CoverageCount) Missing() int { return c.Total - c.Described }
+
+// Percent returns the percentage of members with a description.
+// If there aren't any members, it is 100.
+//
+func (c CoverageCount) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return 100 * float64(c.Described) / float64(c.Total)
+}
+
+func (c *CoverageCount) count(g *ast.DocGroup) {
+	c.Total++
+	if Description(g) != "" {
+		c.Described++
+	}
+}
+
+func (c CoverageCount) plus(o CoverageCount) CoverageCount {
+	return CoverageCount{Described: c.Described + o.Described, Total: c.Total + o.Total}
+}
+
+// Coverage represents the description coverage of a Document.
+type Coverage struct {
+	// Name of the Document
+	Doc string
+
+	// Type definitions, excluding schema and directive definitions. A type
+	// is described if its definition, or any of its extensions, is.
+	Types CoverageCount
+
+	// Object, interface and input object fields
+	Fields CoverageCount
+
+	// Field and directive arguments
+	Args CoverageCount
+
+	// Enum values
+	Values CoverageCount
+}
+
+// Total returns the combined coverage of every kind of member.
+func (c Coverage) Total() CoverageCount {
+	return c.Types.plus(c.Fields).plus(c.Args).plus(c.Values)
+}
+
+// DescriptionCoverage returns the description coverage of each Document
+// in the IR, in Document order. Builtin types are excluded.
+//
+func DescriptionCoverage(ir IR) (cov []Coverage) {
+	for _, doc := range ir.Documents() {
+		if IsBuiltins(doc) {
+			continue
+		}
+
+		cov = append(cov, docCoverage(doc, ir[doc]))
+	}
+	return
+}
+
+func docCoverage(doc *ast.Document, types map[string][]*ast.TypeDecl) Coverage {
+	c := Coverage{Doc: doc.Name}
+	for _, name := range TypeNames(types) {
+		decls := types[name]
+
+		switch DeclTok(decls[0]) {
+		case token.Token_SCHEMA:
+			continue
+		case token.Token_DIRECTIVE:
+		default:
+			g := &ast.DocGroup{}
+			for _, decl := range decls {
+				if decl.Doc != nil {
+					g.List = append(g.List, decl.Doc.List...)
+				}
+			}
+			c.Types.count(g)
+		}
+
+		for _, decl := range decls {
+			switch v := TypeSpecOf(decl).Type.(type) {
+			case *ast.TypeSpec_Object:
+				c.countFields(v.Object.Fields, &c.Fields)
+			case *ast.TypeSpec_Interface:
+				c.countFields(v.Interface.Fields, &c.Fields)
+			case *ast.TypeSpec_Enum:
+				c.countFields(v.Enum.Values, &c.Values)
+			case *ast.TypeSpec_Input:
+				c.countArgs(v.Input.Fields, &c.Fields)
+			case *ast.TypeSpec_Directive:
+				c.countArgs(v.Directive.Args, &c.Args)
+			}
+		}
+	}
+	return c
+}
+
+func (c *Coverage) countFields(fields *ast.FieldList, count *CoverageCount) {
+	if fields == nil {
+		return
+	}
+
+	for _, f := range fields.List {
+		count.count(f.Doc)
+		c.countArgs(f.Args, &c.Args)
+	}
+}
+
+func (c *Coverage) countArgs(args *ast.InputValueList, count *CoverageCount) {
+	if args == nil {
+		return
+	}
+
+	for _, a := range args.List {
+		count.count(a.Doc)
+	}
+}
+
+// WriteCoverage writes a markdown table of the percentage of members without
+// a description, for each Document and in total.
+//
+func WriteCoverage(w io.Writer, cov []Coverage) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("| Document | Types | Fields | Arguments | Enum values | Total |\n")
+	bw.WriteString("|---|---|---|---|---|---|\n")
+
+	var total Coverage
+	for _, c := range cov {
+		writeCoverageRow(bw, c.Doc, c)
+
+		total.Types = total.Types.plus(c.Types)
+		total.Fields = total.Fields.plus(c.Fields)
+		total.Args = total.Args.plus(c.Args)
+		total.Values = total.Values.plus(c.Values)
+	}
+	writeCoverageRow(bw, "**Total**", total)
+
+	return bw.Flush()
+}
+
+func writeCoverageRow(bw *bufio.Writer, name string, c Coverage) {
+	bw.WriteString("| ")
+	bw.WriteString(name)
+	for _, count := range []CoverageCount{c.Types, c.Fields, c.Args, c.Values, c.Total()} {
+		fmt.Fprintf(bw, " | %.1f%% (%d/%d)", 100-count.Percent(), count.Missing(), count.Total)
+	}
+	bw.WriteString(" |\n")
+}
+
+// CoverageChecker returns a TypeChecker which reports every Document whose
+// total description coverage is below min percent, e.g. 80.
+//
+func CoverageChecker(min float64) TypeChecker {
+	return TypeCheckerFn(func(ir IR) (errs []error) {
+		for _, doc := range ir.Documents() {
+			if IsBuiltins(doc) {
+				continue
+			}
+
+			total := docCoverage(doc, ir[doc]).Total()
+			if total.Percent() >= min {
+				continue
+			}
+
+			errs = append(errs, &TypeError{
+				Doc: doc,
+				Msg: fmt.Sprintf("description coverage is below %.1f%%: %.1f%%, %d of %d members are missing a description", min, total.Percent(), total.Missing(), total.Total),
+			})
+		}
+		return
+	})
+}
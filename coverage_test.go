@@ -0,0 +1,82 @@
+package compiler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestDescriptionCoverage(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`schema {
+	query: Query
+}
+
+"The root query."
+type Query {
+	"Find users."
+	users(
+		"Filter by name."
+		name: String
+		limit: Int
+	): [User]
+}
+
+type User {
+	id: ID!
+	role: Role
+}
+
+extend type User {
+	"The user's name."
+	name: String
+}
+
+"A user's role."
+enum Role {
+	ADMIN
+	"A regular user."
+	USER
+}
+
+"Caches a field."
+directive @cached(ttl: Int) on FIELD_DEFINITION`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := ToIR([]*ast.Document{doc})
+
+	cov := DescriptionCoverage(ir)
+	if len(cov) != 1 {
+		t.Fatalf("expected coverage of one document but got: %d", len(cov))
+	}
+
+	expected := Coverage{
+		Doc:    "test",
+		Types:  CoverageCount{Described: 2, Total: 3},
+		Fields: CoverageCount{Described: 2, Total: 4},
+		Args:   CoverageCount{Described: 1, Total: 3},
+		Values: CoverageCount{Described: 1, Total: 2},
+	}
+	if cov[0] != expected {
+		t.Errorf("expected coverage: %+v but got: %+v", expected, cov[0])
+	}
+
+	var b bytes.Buffer
+	if err := WriteCoverage(&b, cov); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "| test | 33.3% (1/3) | 50.0% (2/4) | 66.7% (2/3) | 50.0% (1/2) | 50.0% (6/12) |") {
+		t.Errorf("unexpected coverage report:\n%s", b.String())
+	}
+
+	if errs := CheckTypes(ir, CoverageChecker(50)); len(errs) != 0 {
+		t.Errorf("expected no errors but got: %v", errs)
+	}
+	if errs := CheckTypes(ir, CoverageChecker(80)); len(errs) != 1 {
+		t.Errorf("expected one error but got: %v", errs)
+	}
+}
@@ -0,0 +1,83 @@
+package compiler
+
+import (
+	"sort"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// DefaultDeprecationReason is the reason used when @deprecated is
+// applied without one, per the GraphQL spec.
+//
+const DefaultDeprecationReason = "No longer supported"
+
+// DeprecationReason returns the reason given by an applied @deprecated
+// directive, and whether the directive was applied at all.
+//
+func DeprecationReason(dirs []*ast.DirectiveLit) (reason string, ok bool) {
+	for _, d := range dirs {
+		if d.Name != "deprecated" {
+			continue
+		}
+
+		reason = DefaultDeprecationReason
+		if d.Args == nil {
+			return reason, true
+		}
+
+		for _, arg := range d.Args.Args {
+			if arg.Name == nil || arg.Name.Name != "reason" {
+				continue
+			}
+
+			if vals := ArgStrings(arg); len(vals) > 0 {
+				reason = vals[0]
+			}
+		}
+		return reason, true
+	}
+	return "", false
+}
+
+// Deprecation represents a deprecated field or enum value.
+type Deprecation struct {
+	Ref
+
+	// Reason given for the deprecation
+	Reason string
+}
+
+// Deprecations returns every deprecated member in the IR, sorted
+// by Document, type and field name.
+//
+func Deprecations(ir IR) (deps []Deprecation) {
+	for doc, mdecls := range ir {
+		for name, decls := range mdecls {
+			for _, decl := range decls {
+				walkDirectives(TypeSpecOf(decl), func(field string, d *ast.DirectiveLit) {
+					reason, ok := DeprecationReason([]*ast.DirectiveLit{d})
+					if !ok {
+						return
+					}
+
+					deps = append(deps, Deprecation{
+						Ref:    Ref{Doc: doc, Type: name, Field: field},
+						Reason: reason,
+					})
+				})
+			}
+		}
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		a, b := deps[i], deps[j]
+		if a.Doc.Name != b.Doc.Name {
+			return a.Doc.Name < b.Doc.Name
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Field < b.Field
+	})
+	return
+}
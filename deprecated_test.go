@@ -0,0 +1,48 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestDeprecations(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`type User {
+	name: String @deprecated(reason: "Use fullName.")
+	fullName: String
+}
+
+enum Role {
+	ADMIN
+	GUEST @deprecated
+}
+
+extend type User {
+	age: Int @deprecated(reason: "Use birthday.")
+}`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{
+		"Role.GUEST: No longer supported",
+		"User.age: Use birthday.",
+		"User.name: Use fullName.",
+	}
+
+	deps := Deprecations(ToIR([]*ast.Document{doc}))
+	if len(deps) != len(expected) {
+		t.Errorf("expected %d deprecations but got: %v", len(expected), deps)
+		return
+	}
+
+	for i, d := range deps {
+		if s := d.Type + "." + d.Field + ": " + d.Reason; s != expected[i] {
+			t.Errorf("expected deprecation: %s, but got: %s", expected[i], s)
+		}
+	}
+}
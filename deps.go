@@ -0,0 +1,73 @@
+package compiler
+
+import (
+	"sort"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// Ref represents a reference from one type to another.
+type Ref struct {
+	// Document the referencing type is declared in
+	Doc *ast.Document
+
+	// Name of the referencing type
+	Type string
+
+	// Field, argument ("field.arg") or enum value which holds the
+	// reference. It is empty for implemented interfaces, union members,
+	// and directives applied to the type itself.
+	Field string
+}
+
+// DepIndex is a reverse dependency index, which maps a type or
+// directive name to every reference made to it.
+//
+type DepIndex map[string][]Ref
+
+// NewDepIndex indexes every reference made in the IR. References made
+// by type extensions are attributed to the type they extend.
+//
+func NewDepIndex(ir IR) DepIndex {
+	idx := make(DepIndex)
+	for doc, mdecls := range ir {
+		for name, decls := range mdecls {
+			for _, decl := range decls {
+				ts := TypeSpecOf(decl)
+
+				walkRefs(ts, func(field string, id *ast.Ident) {
+					idx[id.Name] = append(idx[id.Name], Ref{Doc: doc, Type: name, Field: field})
+				})
+				walkDirectives(ts, func(field string, d *ast.DirectiveLit) {
+					idx[d.Name] = append(idx[d.Name], Ref{Doc: doc, Type: name, Field: field})
+				})
+			}
+		}
+	}
+
+	for _, refs := range idx {
+		sort.Slice(refs, func(i, j int) bool {
+			a, b := refs[i], refs[j]
+			if a.Doc.Name != b.Doc.Name {
+				return a.Doc.Name < b.Doc.Name
+			}
+			if a.Type != b.Type {
+				return a.Type < b.Type
+			}
+			return a.Field < b.Field
+		})
+	}
+
+	return idx
+}
+
+// Dependents returns every reference made to the named type or directive.
+func (idx DepIndex) Dependents(name string) []Ref { return idx[name] }
+
+// Dependents returns every type (and field) which references the named
+// type or directive, sorted by Document, type and field name. Use a DepIndex
+// when querying many types.
+//
+func Dependents(ir IR, name string) []Ref {
+	return NewDepIndex(ir).Dependents(name)
+}
@@ -0,0 +1,76 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestDependents(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(renameGQL), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ir := ToIR([]*ast.Document{doc})
+
+	testCases := []struct {
+		Name string
+		Refs []Ref
+	}{
+		{
+			Name: "User",
+			Refs: []Ref{
+				{Doc: doc, Type: "Result"},
+				{Doc: doc, Type: "User", Field: "friends"},
+			},
+		},
+		{
+			Name: "Role",
+			Refs: []Ref{
+				{Doc: doc, Type: "Filter", Field: "role"},
+				{Doc: doc, Type: "auth", Field: "role"},
+			},
+		},
+		{
+			Name: "Filter",
+			Refs: []Ref{
+				{Doc: doc, Type: "User", Field: "friends.filter"},
+			},
+		},
+		{
+			Name: "auth",
+			Refs: []Ref{
+				{Doc: doc, Type: "User", Field: "friends"},
+			},
+		},
+		{
+			Name: "Query",
+			Refs: []Ref{
+				{Doc: doc, Type: "schema", Field: "query"},
+			},
+		},
+		{
+			Name: "Unknown",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			refs := Dependents(ir, testCase.Name)
+			if len(refs) != len(testCase.Refs) {
+				subT.Errorf("expected %d references but got: %v", len(testCase.Refs), refs)
+				return
+			}
+
+			for i, ref := range refs {
+				if ref != testCase.Refs[i] {
+					subT.Errorf("expected reference: %v, but got: %v", testCase.Refs[i], ref)
+				}
+			}
+		})
+	}
+}
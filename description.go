@@ -0,0 +1,130 @@
+package compiler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// Description returns the text of the descriptions in a DocGroup, ignoring
+// any comments. Block strings are dedented, per the GraphQL spec, and
+// multiple descriptions, e.g. from merged extensions, are separated by
+// a blank line.
+//
+func Description(g *ast.DocGroup) string {
+	if g == nil {
+		return ""
+	}
+
+	var descs []string
+	for _, d := range g.List {
+		if d.Comment {
+			continue
+		}
+
+		if s := descriptionText(d.Text); s != "" {
+			descs = append(descs, s)
+		}
+	}
+	return strings.Join(descs, "\n\n")
+}
+
+// DocDescription returns the description of a Document, e.g. for rendering
+// an "About this API" section or package-level docs. A Document is described
+// by the descriptions which precede the description of its first type
+// declaration, see ToIR.
+//
+func DocDescription(doc *ast.Document) string { return Description(doc.Doc) }
+
+// SchemaDescription returns the description of the schema declaration,
+// along with its extensions, or an empty string if there isn't one.
+//
+func SchemaDescription(ir IR) string {
+	for _, doc := range ir.Documents() {
+		var descs []string
+		for _, decl := range ir[doc]["schema"] {
+			if s := Description(decl.Doc); s != "" {
+				descs = append(descs, s)
+			}
+		}
+		if len(descs) > 0 {
+			return strings.Join(descs, "\n\n")
+		}
+	}
+	return ""
+}
+
+// liftDocDescription moves the descriptions, and comments, preceding the
+// description of a Document's first type declaration to the Document. The
+// parser attaches every description before the first declaration to it,
+// but only the last one describes the declaration.
+//
+func liftDocDescription(doc *ast.Document) {
+	if len(doc.Types) == 0 || doc.Types[0].Doc == nil {
+		return
+	}
+	g := doc.Types[0].Doc
+
+	last := -1
+	for i, d := range g.List {
+		if !d.Comment {
+			last = i
+		}
+	}
+	if last <= 0 {
+		return
+	}
+
+	if doc.Doc == nil {
+		doc.Doc = &ast.DocGroup{}
+	}
+	doc.Doc.List = append(doc.Doc.List, g.List[:last]...)
+	g.List = g.List[last:]
+}
+
+// descriptionText returns the value of a string or block string literal.
+func descriptionText(lit string) string {
+	if strings.HasPrefix(lit, `"""`) {
+		return blockString(strings.TrimSuffix(strings.TrimPrefix(lit, `"""`), `"""`))
+	}
+
+	s, err := strconv.Unquote(lit)
+	if err != nil {
+		s = strings.Trim(lit, `"`)
+	}
+	return strings.TrimSpace(s)
+}
+
+// blockString returns the value of a block string, per the GraphQL spec:
+// the common indentation of every line but the first is removed, along
+// with any leading and trailing blank lines.
+//
+func blockString(raw string) string {
+	lines := strings.Split(strings.Replace(raw, `\"""`, `"""`, -1), "\n")
+
+	indent := -1
+	for _, l := range lines[1:] {
+		n := len(l) - len(strings.TrimLeft(l, " \t"))
+		if n < len(l) && (indent < 0 || n < indent) {
+			indent = n
+		}
+	}
+	if indent > 0 {
+		for i, l := range lines[1:] {
+			if len(l) >= indent {
+				lines[i+1] = l[indent:]
+			} else {
+				lines[i+1] = ""
+			}
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
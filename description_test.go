@@ -0,0 +1,86 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestDescriptions(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Src    string
+		Doc    string
+		Type   string
+		Schema string
+	}{
+		{
+			Name: "None",
+			Src:  "type Query { a: Int }",
+		},
+		{
+			Name: "Type",
+			Src:  "\"The root query.\"\ntype Query { a: Int }",
+			Type: "The root query.",
+		},
+		{
+			Name: "Document",
+			Src: `"""
+	About this API.
+
+	  It's great.
+"""
+
+# Not a description
+
+"The root query."
+type Query { a: Int }`,
+			Doc:  "About this API.\n\n  It's great.",
+			Type: "The root query.",
+		},
+		{
+			Name: "Schema",
+			Src: `"""About this API."""
+
+"The schema."
+schema { query: Query }
+
+"The root query."
+type Query { a: Int }`,
+			Doc:    "About this API.",
+			Schema: "The schema.",
+		},
+		{
+			Name: "Escapes",
+			Src:  "\"Caf\\u00e9\"\ntype Query { a: Int }",
+			Type: "Café",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(testCase.Src), parser.ParseComments)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			ir := ToIR([]*ast.Document{doc})
+
+			if d := DocDescription(doc); d != testCase.Doc {
+				subT.Errorf("expected document description: %q but got: %q", testCase.Doc, d)
+			}
+			if d := SchemaDescription(ir); d != testCase.Schema {
+				subT.Errorf("expected schema description: %q but got: %q", testCase.Schema, d)
+			}
+
+			if testCase.Schema != "" {
+				return
+			}
+			if d := Description(ir[doc]["Query"][0].Doc); d != testCase.Type {
+				subT.Errorf("expected type description: %q but got: %q", testCase.Type, d)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package compiler
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gqlc/graphql/ast"
+)
+
+// WriteIR serializes the IR as a descriptor set, analogous to protoc's
+// descriptor_set_out, so that expensive import resolution and merging
+// only needs to happen once across tool invocations. Each Document is
+// written, sorted by name, as a varint length-prefixed ast.Document
+// protobuf message.
+//
+func WriteIR(w io.Writer, ir IR) error {
+	var prefix [binary.MaxVarintLen64]byte
+	for _, doc := range ir.Documents() {
+		if IsBuiltins(doc) {
+			continue
+		}
+
+		// Copies are sorted and written, so the IR is left untouched
+		out := &ast.Document{Name: doc.Name, Doc: doc.Doc, Directives: doc.Directives, Schema: doc.Schema}
+		for _, decls := range ir[doc] {
+			out.Types = append(out.Types, decls...)
+		}
+		sort.Stable(byTypeAndName{types: &out.Types})
+
+		b, err := proto.Marshal(out)
+		if err != nil {
+			return fmt.Errorf("compiler: failed to encode %s: %s", doc.Name, err)
+		}
+
+		n := binary.PutUvarint(prefix[:], uint64(len(b)))
+		if _, err = w.Write(prefix[:n]); err != nil {
+			return err
+		}
+		if _, err = w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadIR reads a descriptor set written by WriteIR.
+func ReadIR(r io.Reader) (IR, error) {
+	br := bufio.NewReader(r)
+
+	var docs []*ast.Document
+	for {
+		size, err := binary.ReadUvarint(br)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("compiler: malformed descriptor set: %s", err)
+		}
+
+		// The size is untrusted, so the document is read as it arrives,
+		// rather than allocating the buffer up front.
+		if size > math.MaxInt64 {
+			return nil, fmt.Errorf("compiler: malformed descriptor set: document size %d is too large", size)
+		}
+		b, err := ioutil.ReadAll(io.LimitReader(br, int64(size)))
+		if err != nil {
+			return nil, fmt.Errorf("compiler: malformed descriptor set: %s", err)
+		}
+		if uint64(len(b)) != size {
+			return nil, fmt.Errorf("compiler: malformed descriptor set: %s", io.ErrUnexpectedEOF)
+		}
+
+		doc := new(ast.Document)
+		if err = proto.Unmarshal(b, doc); err != nil {
+			return nil, fmt.Errorf("compiler: malformed descriptor set: %s", err)
+		}
+		docs = append(docs, doc)
+	}
+
+	return ToIR(docs), nil
+}
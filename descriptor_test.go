@@ -0,0 +1,107 @@
+package compiler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestDescriptorSet(t *testing.T) {
+	dset := token.NewDocSet()
+
+	var docs []*ast.Document
+	for name, src := range map[string]string{
+		"a": `@import(paths: ["b"])
+
+type A {
+	b: B @deprecated(reason: "Use c.")
+}`,
+		"b": `"B is a scalar."
+scalar B`,
+	} {
+		doc, err := parser.ParseDoc(dset, name, strings.NewReader(src), 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		docs = append(docs, doc)
+	}
+
+	ir := ToIR(docs)
+
+	var buf bytes.Buffer
+	if err := WriteIR(&buf, ir); err != nil {
+		t.Error(err)
+		return
+	}
+
+	out, err := ReadIR(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if len(out) != 2 {
+		t.Errorf("expected 2 documents but got: %d", len(out))
+		return
+	}
+	if SchemaHash(out) != SchemaHash(ir) {
+		t.Error("expected read IR to describe the same schema")
+	}
+
+	for _, doc := range out.Documents() {
+		if doc.Name == "a" && len(DocImports(doc)) != 1 {
+			t.Errorf("expected document directives to be preserved")
+		}
+	}
+
+	_, err = ReadIR(strings.NewReader("\x05ab"))
+	if err == nil {
+		t.Error("expected error for truncated descriptor set")
+	}
+
+	for _, size := range []string{"\xff\xff\xff\xff\xff\xff\xff\xff\x7f", "\xff\xff\xff\xff\xff\xff\xff\xff\xff\x01"} {
+		_, err = ReadIR(strings.NewReader(size))
+		if err == nil || !strings.Contains(err.Error(), "malformed descriptor set") {
+			t.Errorf("expected error for oversized document but got: %v", err)
+		}
+	}
+}
+
+func TestWriteIRUnmerged(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "a", strings.NewReader(`extend type Query {
+	b: Int
+}
+
+type Foo {
+	a: Int
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	types := append([]*ast.TypeDecl(nil), doc.Types...)
+
+	var buf bytes.Buffer
+	if err := WriteIR(&buf, ToIR([]*ast.Document{doc})); err != nil {
+		t.Fatal(err)
+	}
+	for i, decl := range doc.Types {
+		if decl != types[i] {
+			t.Fatal("expected the types of the document to be left untouched")
+		}
+	}
+
+	out, err := ReadIR(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for d := range out {
+		if names := TypeNames(out[d]); len(d.Types) != 2 || declName(d.Types[0]) != "Foo" || len(names) != 2 {
+			t.Errorf("expected both types to be written in order but got: %v", names)
+		}
+	}
+}
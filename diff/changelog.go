@@ -0,0 +1,83 @@
+package diff
+
+import (
+	"bufio"
+	"io"
+)
+
+// section groups changes in a changelog.
+type section uint8
+
+const (
+	added section = iota
+	removed
+	deprecated
+	changed
+)
+
+var sectionTitles = [...]string{
+	added:      "Added",
+	removed:    "Removed",
+	deprecated: "Deprecated",
+	changed:    "Changed",
+}
+
+func sectionOf(k Kind) section {
+	switch k {
+	case TypeAdded, FieldAdded, ArgAdded, RequiredArgAdded, InputFieldAdded, RequiredInputFieldAdded,
+		EnumValueAdded, UnionMemberAdded, InterfaceAdded, RootOpAdded, DirectiveLocAdded:
+		return added
+	case TypeRemoved, FieldRemoved, ArgRemoved, InputFieldRemoved, EnumValueRemoved,
+		UnionMemberRemoved, InterfaceRemoved, RootOpRemoved, DirectiveLocRemoved:
+		return removed
+	case FieldDeprecated, EnumValueDeprecated:
+		return deprecated
+	}
+	return changed
+}
+
+// WriteChangelog writes a markdown changelog section, suitable for a
+// CHANGELOG.md, describing the given changes. Changes are grouped
+// into added, removed, deprecated, and changed sub-sections, with
+// breaking changes marked as such.
+//
+func WriteChangelog(w io.Writer, title string, changes []Change) error {
+	var groups [len(sectionTitles)][]Change
+	for _, c := range changes {
+		s := sectionOf(c.Kind)
+		groups[s] = append(groups[s], c)
+	}
+
+	bw := bufio.NewWriter(w)
+	bw.WriteString("## ")
+	bw.WriteString(title)
+	bw.WriteString("\n")
+
+	if len(changes) == 0 {
+		bw.WriteString("\nNo changes.\n")
+	}
+
+	for s, cs := range groups {
+		if len(cs) == 0 {
+			continue
+		}
+
+		bw.WriteString("\n### ")
+		bw.WriteString(sectionTitles[s])
+		bw.WriteString("\n\n")
+
+		for _, c := range cs {
+			bw.WriteString("- ")
+			if c.Severity == Breaking {
+				bw.WriteString("**Breaking:** ")
+			}
+			bw.WriteString("`")
+			bw.WriteString(c.Path)
+			bw.WriteString("`: ")
+			bw.WriteString(c.Msg)
+			bw.WriteString("\n")
+		}
+	}
+
+	return bw.Flush()
+}
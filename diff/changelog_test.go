@@ -0,0 +1,60 @@
+package diff
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteChangelog(t *testing.T) {
+	old, err := parseIR(`type User {
+	id: ID!
+	name: String
+	email: String
+}
+
+enum Role {
+	ADMIN
+	USER
+}`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	new, err := parseIR(`type User {
+	id: ID!
+	name: String @deprecated(reason: "use fullName")
+	fullName: String
+}
+
+enum Role {
+	ADMIN
+	USER @deprecated
+}
+
+scalar Time`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	var b bytes.Buffer
+	err = WriteChangelog(&b, "v1.1.0", Compare(old, new))
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ex := "## v1.1.0\n" +
+		"\n### Added\n\n" +
+		"- `Time`: scalar was added\n" +
+		"- `User.fullName`: field was added\n" +
+		"\n### Removed\n\n" +
+		"- **Breaking:** `User.email`: field was removed\n" +
+		"\n### Deprecated\n\n" +
+		"- `Role.USER`: enum value was deprecated\n" +
+		"- `User.name`: field was deprecated\n"
+	if b.String() != ex {
+		t.Errorf("expected changelog:\n%s\nbut got:\n%s", ex, b.String())
+	}
+}
@@ -0,0 +1,77 @@
+package diff
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gqlc/compiler"
+)
+
+// BreakingError represents a breaking change which hasn't been acknowledged.
+type BreakingError struct {
+	Change Change
+}
+
+func (e *BreakingError) Error() string {
+	return fmt.Sprintf("diff: breaking change: %s: %s", e.Change.Path, e.Change.Msg)
+}
+
+// Allowlist contains acknowledged breaking changes.
+//
+// Each line of an allowlist file acknowledges either every change
+// to a path, or only a single kind of change to it:
+//
+//	# Comments and blank lines are ignored
+//	User.name
+//	FIELD_TYPE_CHANGED User.id
+//
+type Allowlist map[string]bool
+
+// ReadAllowlist reads an Allowlist.
+func ReadAllowlist(r io.Reader) (Allowlist, error) {
+	l := make(Allowlist)
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		parts := strings.Fields(text)
+		switch len(parts) {
+		case 1:
+			l[parts[0]] = true
+		case 2:
+			l[parts[0]+" "+parts[1]] = true
+		default:
+			return nil, fmt.Errorf("diff: malformed allowlist entry on line %d: %s", line, text)
+		}
+	}
+
+	return l, s.Err()
+}
+
+// Allows reports whether the change has been acknowledged.
+func (l Allowlist) Allows(c Change) bool {
+	return l[c.Path] || l[string(c.Kind)+" "+c.Path]
+}
+
+// Checker returns a TypeChecker which reports every breaking change,
+// from the old schema, which isn't acknowledged by the allowlist.
+// The allowlist may be nil.
+//
+func Checker(old compiler.IR, allow Allowlist) compiler.TypeChecker {
+	return compiler.TypeCheckerFn(func(ir compiler.IR) (errs []error) {
+		for _, c := range Compare(old, ir) {
+			if c.Severity != Breaking || allow.Allows(c) {
+				continue
+			}
+
+			errs = append(errs, &BreakingError{Change: c})
+		}
+		return
+	})
+}
@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+)
+
+func TestChecker(t *testing.T) {
+	old, err := parseIR(`type A {
+	a: String
+	b: String
+	c: Int
+}`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	testCases := []struct {
+		Name  string
+		Allow string
+		Errs  []string
+	}{
+		{
+			Name: "NoAllowlist",
+			Errs: []string{
+				"diff: breaking change: A.a: field was removed",
+				"diff: breaking change: A.c: field type changed from Int to String",
+			},
+		},
+		{
+			Name: "Allowlist",
+			Allow: `# acknowledged
+A.a
+
+FIELD_TYPE_CHANGED A.c`,
+		},
+		{
+			Name:  "WrongKind",
+			Allow: `FIELD_REMOVED A.c`,
+			Errs: []string{
+				"diff: breaking change: A.a: field was removed",
+				"diff: breaking change: A.c: field type changed from Int to String",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			allow, err := ReadAllowlist(strings.NewReader(testCase.Allow))
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			new, err := parseIR(`type A {
+	b: String
+	c: String
+	d: Int
+}`)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			errs := compiler.CheckTypes(new, Checker(old, allow))
+			if len(errs) != len(testCase.Errs) {
+				subT.Errorf("expected %d errors but got: %v", len(testCase.Errs), errs)
+				return
+			}
+
+			for i, err := range errs {
+				if err.Error() != testCase.Errs[i] {
+					subT.Errorf("expected error: %s, but got: %s", testCase.Errs[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestReadAllowlist_Malformed(t *testing.T) {
+	_, err := ReadAllowlist(strings.NewReader("FIELD_REMOVED A.a extra"))
+	if err == nil {
+		t.Fail()
+	}
+}
@@ -0,0 +1,390 @@
+// Package diff compares GraphQL schemas and classifies their changes.
+package diff
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// Severity classifies how a Change impacts existing clients.
+type Severity uint8
+
+const (
+	// Safe changes can not break existing clients.
+	Safe Severity = iota
+
+	// Dangerous changes won't break existing clients at validation
+	// time, but may change their runtime behaviour.
+	Dangerous
+
+	// Breaking changes will break existing clients.
+	Breaking
+)
+
+var severities = [...]string{
+	Safe:      "safe",
+	Dangerous: "dangerous",
+	Breaking:  "breaking",
+}
+
+func (s Severity) String() string {
+	if int(s) < len(severities) {
+		return severities[s]
+	}
+	return fmt.Sprintf("Severity(%d)", s)
+}
+
+// Kind identifies the kind of Change.
+type Kind string
+
+// Kinds of changes.
+const (
+	TypeAdded               Kind = "TYPE_ADDED"
+	TypeRemoved             Kind = "TYPE_REMOVED"
+	TypeKindChanged         Kind = "TYPE_KIND_CHANGED"
+	FieldAdded              Kind = "FIELD_ADDED"
+	FieldRemoved            Kind = "FIELD_REMOVED"
+	FieldTypeChanged        Kind = "FIELD_TYPE_CHANGED"
+	FieldDeprecated         Kind = "FIELD_DEPRECATED"
+	ArgAdded                Kind = "ARG_ADDED"
+	RequiredArgAdded        Kind = "REQUIRED_ARG_ADDED"
+	ArgRemoved              Kind = "ARG_REMOVED"
+	ArgTypeChanged          Kind = "ARG_TYPE_CHANGED"
+	ArgDefaultChanged       Kind = "ARG_DEFAULT_CHANGED"
+	InputFieldAdded         Kind = "INPUT_FIELD_ADDED"
+	RequiredInputFieldAdded Kind = "REQUIRED_INPUT_FIELD_ADDED"
+	InputFieldRemoved       Kind = "INPUT_FIELD_REMOVED"
+	InputFieldTypeChanged   Kind = "INPUT_FIELD_TYPE_CHANGED"
+	EnumValueAdded          Kind = "ENUM_VALUE_ADDED"
+	EnumValueRemoved        Kind = "ENUM_VALUE_REMOVED"
+	EnumValueDeprecated     Kind = "ENUM_VALUE_DEPRECATED"
+	UnionMemberAdded        Kind = "UNION_MEMBER_ADDED"
+	UnionMemberRemoved      Kind = "UNION_MEMBER_REMOVED"
+	InterfaceAdded          Kind = "INTERFACE_ADDED"
+	InterfaceRemoved        Kind = "INTERFACE_REMOVED"
+	RootOpAdded             Kind = "ROOT_OPERATION_ADDED"
+	RootOpRemoved           Kind = "ROOT_OPERATION_REMOVED"
+	RootOpChanged           Kind = "ROOT_OPERATION_CHANGED"
+	DirectiveLocAdded       Kind = "DIRECTIVE_LOCATION_ADDED"
+	DirectiveLocRemoved     Kind = "DIRECTIVE_LOCATION_REMOVED"
+)
+
+// Change represents a single difference between two schemas.
+type Change struct {
+	Kind     Kind
+	Severity Severity
+
+	// Path to the changed element, e.g. "User", "User.name" or "User.friends.first".
+	// Directive paths are prefixed with "@".
+	Path string
+
+	// Msg is a human-readable description of the change
+	Msg string
+}
+
+// String returns a string representation of a Change.
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %s: %s", c.Severity, c.Path, c.Msg)
+}
+
+// Compare compares an old and new schema and returns every Change
+// between them, sorted by path, kind and message.
+//
+func Compare(old, new compiler.IR) (changes []Change) {
+	oTypes, nTypes := flatten(old), flatten(new)
+
+	for name, o := range oTypes {
+		n, ok := nTypes[name]
+		if !ok {
+			changes = append(changes, Change{Kind: TypeRemoved, Severity: Breaking, Path: o.path(name), Msg: fmt.Sprintf("%s was removed", o.kind())})
+			continue
+		}
+
+		if o.tok != n.tok {
+			changes = append(changes, Change{Kind: TypeKindChanged, Severity: Breaking, Path: o.path(name), Msg: fmt.Sprintf("changed from %s to %s", o.kind(), n.kind())})
+			continue
+		}
+
+		compareType(&changes, name, o, n)
+	}
+
+	for name, n := range nTypes {
+		if _, ok := oTypes[name]; ok {
+			continue
+		}
+
+		changes = append(changes, Change{Kind: TypeAdded, Severity: Safe, Path: n.path(name), Msg: fmt.Sprintf("%s was added", n.kind())})
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Path != changes[j].Path {
+			return changes[i].Path < changes[j].Path
+		}
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Msg < changes[j].Msg
+	})
+	return
+}
+
+// HasBreaking returns whether any of the changes are breaking.
+func HasBreaking(changes []Change) bool {
+	for _, c := range changes {
+		if c.Severity == Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+func compareType(changes *[]Change, name string, o, n *typeInfo) {
+	switch o.tok {
+	case token.Token_SCHEMA:
+		compareRootOps(changes, o, n)
+	case token.Token_TYPE, token.Token_INTERFACE:
+		compareFields(changes, name, o, n)
+		compareSet(changes, name, o.members, n.members, InterfaceAdded, InterfaceRemoved, "interface")
+	case token.Token_UNION:
+		compareSet(changes, name, o.members, n.members, UnionMemberAdded, UnionMemberRemoved, "member")
+	case token.Token_ENUM:
+		compareEnumValues(changes, name, o, n)
+	case token.Token_INPUT:
+		compareInputFields(changes, name, o.args, n.args)
+	case token.Token_DIRECTIVE:
+		compareArgs(changes, "@"+name, o.args, n.args)
+		compareLocs(changes, "@"+name, o.locs, n.locs)
+	}
+}
+
+func compareRootOps(changes *[]Change, o, n *typeInfo) {
+	for op, of := range o.fields {
+		nf, ok := n.fields[op]
+		if !ok {
+			*changes = append(*changes, Change{Kind: RootOpRemoved, Severity: Breaking, Path: "schema." + op, Msg: "root operation was removed"})
+			continue
+		}
+
+		if ot, nt := compiler.TypeString(fieldType(of)), compiler.TypeString(fieldType(nf)); ot != nt {
+			*changes = append(*changes, Change{Kind: RootOpChanged, Severity: Breaking, Path: "schema." + op, Msg: fmt.Sprintf("root operation type changed from %s to %s", ot, nt)})
+		}
+	}
+
+	for op := range n.fields {
+		if _, ok := o.fields[op]; ok {
+			continue
+		}
+
+		*changes = append(*changes, Change{Kind: RootOpAdded, Severity: Safe, Path: "schema." + op, Msg: "root operation was added"})
+	}
+}
+
+func compareFields(changes *[]Change, name string, o, n *typeInfo) {
+	for fname, of := range o.fields {
+		path := name + "." + fname
+
+		nf, ok := n.fields[fname]
+		if !ok {
+			*changes = append(*changes, Change{Kind: FieldRemoved, Severity: Breaking, Path: path, Msg: "field was removed"})
+			continue
+		}
+
+		ot, nt := fieldType(of), fieldType(nf)
+		if !isSafeOutputChange(ot, nt) {
+			*changes = append(*changes, Change{Kind: FieldTypeChanged, Severity: Breaking, Path: path, Msg: fmt.Sprintf("field type changed from %s to %s", compiler.TypeString(ot), compiler.TypeString(nt))})
+		} else if compiler.TypeString(ot) != compiler.TypeString(nt) {
+			*changes = append(*changes, Change{Kind: FieldTypeChanged, Severity: Safe, Path: path, Msg: fmt.Sprintf("field type changed from %s to %s", compiler.TypeString(ot), compiler.TypeString(nt))})
+		}
+
+		if !isDeprecated(of) && isDeprecated(nf) {
+			*changes = append(*changes, Change{Kind: FieldDeprecated, Severity: Safe, Path: path, Msg: "field was deprecated"})
+		}
+
+		compareArgs(changes, path, argMap(of.Args), argMap(nf.Args))
+	}
+
+	for fname := range n.fields {
+		if _, ok := o.fields[fname]; ok {
+			continue
+		}
+
+		*changes = append(*changes, Change{Kind: FieldAdded, Severity: Safe, Path: name + "." + fname, Msg: "field was added"})
+	}
+}
+
+func compareArgs(changes *[]Change, host string, o, n map[string]*ast.InputValue) {
+	for aname, oa := range o {
+		path := host + "." + aname
+
+		na, ok := n[aname]
+		if !ok {
+			*changes = append(*changes, Change{Kind: ArgRemoved, Severity: Breaking, Path: path, Msg: "argument was removed"})
+			continue
+		}
+
+		ot, nt := inputType(oa), inputType(na)
+		if !isSafeInputChange(ot, nt) {
+			*changes = append(*changes, Change{Kind: ArgTypeChanged, Severity: Breaking, Path: path, Msg: fmt.Sprintf("argument type changed from %s to %s", compiler.TypeString(ot), compiler.TypeString(nt))})
+		} else if compiler.TypeString(ot) != compiler.TypeString(nt) {
+			*changes = append(*changes, Change{Kind: ArgTypeChanged, Severity: Safe, Path: path, Msg: fmt.Sprintf("argument type changed from %s to %s", compiler.TypeString(ot), compiler.TypeString(nt))})
+		}
+
+		if od, nd := compiler.DefaultString(oa), compiler.DefaultString(na); od != nd {
+			*changes = append(*changes, Change{Kind: ArgDefaultChanged, Severity: Dangerous, Path: path, Msg: fmt.Sprintf("default value changed from %q to %q", od, nd)})
+		}
+	}
+
+	for aname, na := range n {
+		if _, ok := o[aname]; ok {
+			continue
+		}
+
+		if isRequired(na) {
+			*changes = append(*changes, Change{Kind: RequiredArgAdded, Severity: Breaking, Path: host + "." + aname, Msg: "required argument was added"})
+			continue
+		}
+
+		*changes = append(*changes, Change{Kind: ArgAdded, Severity: Dangerous, Path: host + "." + aname, Msg: "optional argument was added"})
+	}
+}
+
+func compareInputFields(changes *[]Change, name string, o, n map[string]*ast.InputValue) {
+	for fname, of := range o {
+		path := name + "." + fname
+
+		nf, ok := n[fname]
+		if !ok {
+			*changes = append(*changes, Change{Kind: InputFieldRemoved, Severity: Breaking, Path: path, Msg: "input field was removed"})
+			continue
+		}
+
+		ot, nt := inputType(of), inputType(nf)
+		if !isSafeInputChange(ot, nt) {
+			*changes = append(*changes, Change{Kind: InputFieldTypeChanged, Severity: Breaking, Path: path, Msg: fmt.Sprintf("input field type changed from %s to %s", compiler.TypeString(ot), compiler.TypeString(nt))})
+		} else if compiler.TypeString(ot) != compiler.TypeString(nt) {
+			*changes = append(*changes, Change{Kind: InputFieldTypeChanged, Severity: Safe, Path: path, Msg: fmt.Sprintf("input field type changed from %s to %s", compiler.TypeString(ot), compiler.TypeString(nt))})
+		}
+	}
+
+	for fname, nf := range n {
+		if _, ok := o[fname]; ok {
+			continue
+		}
+
+		if isRequired(nf) {
+			*changes = append(*changes, Change{Kind: RequiredInputFieldAdded, Severity: Breaking, Path: name + "." + fname, Msg: "required input field was added"})
+			continue
+		}
+
+		*changes = append(*changes, Change{Kind: InputFieldAdded, Severity: Dangerous, Path: name + "." + fname, Msg: "optional input field was added"})
+	}
+}
+
+func compareEnumValues(changes *[]Change, name string, o, n *typeInfo) {
+	for v, ov := range o.fields {
+		nv, ok := n.fields[v]
+		if !ok {
+			*changes = append(*changes, Change{Kind: EnumValueRemoved, Severity: Breaking, Path: name + "." + v, Msg: "enum value was removed"})
+			continue
+		}
+
+		if !isDeprecated(ov) && isDeprecated(nv) {
+			*changes = append(*changes, Change{Kind: EnumValueDeprecated, Severity: Safe, Path: name + "." + v, Msg: "enum value was deprecated"})
+		}
+	}
+
+	for v := range n.fields {
+		if _, ok := o.fields[v]; !ok {
+			*changes = append(*changes, Change{Kind: EnumValueAdded, Severity: Dangerous, Path: name + "." + v, Msg: "enum value was added"})
+		}
+	}
+}
+
+func compareSet(changes *[]Change, name string, o, n map[string]bool, added, removed Kind, what string) {
+	for m := range o {
+		if !n[m] {
+			*changes = append(*changes, Change{Kind: removed, Severity: Breaking, Path: name, Msg: fmt.Sprintf("%s %s was removed", what, m)})
+		}
+	}
+
+	for m := range n {
+		if !o[m] {
+			*changes = append(*changes, Change{Kind: added, Severity: Dangerous, Path: name, Msg: fmt.Sprintf("%s %s was added", what, m)})
+		}
+	}
+}
+
+func compareLocs(changes *[]Change, name string, o, n map[ast.DirectiveLocation_Loc]bool) {
+	for l := range o {
+		if !n[l] {
+			*changes = append(*changes, Change{Kind: DirectiveLocRemoved, Severity: Breaking, Path: name, Msg: fmt.Sprintf("location %s was removed", l)})
+		}
+	}
+
+	for l := range n {
+		if !o[l] {
+			*changes = append(*changes, Change{Kind: DirectiveLocAdded, Severity: Safe, Path: name, Msg: fmt.Sprintf("location %s was added", l)})
+		}
+	}
+}
+
+// isSafeOutputChange reports whether a field type change from o to n
+// can't break clients, i.e. n is equal to or a stricter form of o.
+//
+func isSafeOutputChange(o, n interface{}) bool {
+	switch ot := o.(type) {
+	case *ast.Ident:
+		switch nt := n.(type) {
+		case *ast.Ident:
+			return ot.Name == nt.Name
+		case *ast.NonNull:
+			return isSafeOutputChange(o, unwrapNonNull(nt))
+		}
+	case *ast.List:
+		switch nt := n.(type) {
+		case *ast.List:
+			return isSafeOutputChange(unwrapList(ot), unwrapList(nt))
+		case *ast.NonNull:
+			return isSafeOutputChange(o, unwrapNonNull(nt))
+		}
+	case *ast.NonNull:
+		if nt, ok := n.(*ast.NonNull); ok {
+			return isSafeOutputChange(unwrapNonNull(ot), unwrapNonNull(nt))
+		}
+	}
+	return false
+}
+
+// isSafeInputChange reports whether an input type change from o to n
+// can't break clients, i.e. n is equal to or a looser form of o.
+//
+func isSafeInputChange(o, n interface{}) bool {
+	switch ot := o.(type) {
+	case *ast.Ident:
+		nt, ok := n.(*ast.Ident)
+		return ok && ot.Name == nt.Name
+	case *ast.List:
+		nt, ok := n.(*ast.List)
+		return ok && isSafeInputChange(unwrapList(ot), unwrapList(nt))
+	case *ast.NonNull:
+		if nt, ok := n.(*ast.NonNull); ok {
+			return isSafeInputChange(unwrapNonNull(ot), unwrapNonNull(nt))
+		}
+		return isSafeInputChange(unwrapNonNull(ot), n)
+	}
+	return false
+}
+
+func isDeprecated(f *ast.Field) bool {
+	_, ok := compiler.DeprecationReason(f.Directives)
+	return ok
+}
+
+func isRequired(v *ast.InputValue) bool {
+	_, ok := v.Type.(*ast.InputValue_NonNull)
+	return ok && v.Default == nil
+}
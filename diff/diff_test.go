@@ -0,0 +1,169 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Old, New string
+		Changes  []Change
+	}{
+		{
+			Name: "NoChanges",
+			Old:  `type A { a: String }`,
+			New:  `type A { a: String }`,
+		},
+		{
+			Name: "Types",
+			Old: `type A { a: String }
+
+scalar B`,
+			New: `type A { a: String }
+
+enum B {
+	ONE
+}
+
+scalar C`,
+			Changes: []Change{
+				{Kind: TypeKindChanged, Severity: Breaking, Path: "B"},
+				{Kind: TypeAdded, Severity: Safe, Path: "C"},
+			},
+		},
+		{
+			Name: "Fields",
+			Old: `type A {
+	a: String
+	b: String!
+	c: [String]
+	d: Int
+}`,
+			New: `type A {
+	a: String!
+	b: String
+	c: [String!]!
+	e: Int
+}`,
+			Changes: []Change{
+				{Kind: FieldTypeChanged, Severity: Safe, Path: "A.a"},
+				{Kind: FieldTypeChanged, Severity: Breaking, Path: "A.b"},
+				{Kind: FieldTypeChanged, Severity: Safe, Path: "A.c"},
+				{Kind: FieldRemoved, Severity: Breaking, Path: "A.d"},
+				{Kind: FieldAdded, Severity: Safe, Path: "A.e"},
+			},
+		},
+		{
+			Name: "Args",
+			Old:  `type A { a(x: Int!, y: Int, z: Int = 1): String }`,
+			New:  `type A { a(x: Int, z: Int = 2, r: Int!, o: Int): String }`,
+			Changes: []Change{
+				{Kind: ArgAdded, Severity: Dangerous, Path: "A.a.o"},
+				{Kind: RequiredArgAdded, Severity: Breaking, Path: "A.a.r"},
+				{Kind: ArgTypeChanged, Severity: Safe, Path: "A.a.x"},
+				{Kind: ArgRemoved, Severity: Breaking, Path: "A.a.y"},
+				{Kind: ArgDefaultChanged, Severity: Dangerous, Path: "A.a.z"},
+			},
+		},
+		{
+			Name: "InputFields",
+			Old: `input A {
+	a: Int
+	b: Int
+}`,
+			New: `input A {
+	a: Int!
+	c: Int!
+}`,
+			Changes: []Change{
+				{Kind: InputFieldTypeChanged, Severity: Breaking, Path: "A.a"},
+				{Kind: InputFieldRemoved, Severity: Breaking, Path: "A.b"},
+				{Kind: RequiredInputFieldAdded, Severity: Breaking, Path: "A.c"},
+			},
+		},
+		{
+			Name: "EnumsAndUnions",
+			Old: `enum A {
+	ONE
+	TWO
+}
+
+union U = X | Y`,
+			New: `enum A {
+	ONE
+	THREE
+}
+
+union U = X | Z`,
+			Changes: []Change{
+				{Kind: EnumValueAdded, Severity: Dangerous, Path: "A.THREE"},
+				{Kind: EnumValueRemoved, Severity: Breaking, Path: "A.TWO"},
+				{Kind: UnionMemberAdded, Severity: Dangerous, Path: "U"},
+				{Kind: UnionMemberRemoved, Severity: Breaking, Path: "U"},
+			},
+		},
+		{
+			Name: "Directives",
+			Old:  `directive @a(x: Int) on FIELD | QUERY`,
+			New:  `directive @a(x: Int) on FIELD | MUTATION`,
+			Changes: []Change{
+				{Kind: DirectiveLocAdded, Severity: Safe, Path: "@a"},
+				{Kind: DirectiveLocRemoved, Severity: Breaking, Path: "@a"},
+			},
+		},
+		{
+			Name: "Extensions",
+			Old: `type A { a: String }
+
+extend type A { b: String }`,
+			New: `type A {
+	a: String
+	b: String
+}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			old, err := parseIR(testCase.Old)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+			new, err := parseIR(testCase.New)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			changes := Compare(old, new)
+			if len(changes) != len(testCase.Changes) {
+				subT.Errorf("expected %d changes but got: %v", len(testCase.Changes), changes)
+				return
+			}
+
+			for i, c := range changes {
+				ex := testCase.Changes[i]
+				if c.Kind != ex.Kind || c.Severity != ex.Severity || c.Path != ex.Path {
+					subT.Errorf("expected change: %s %s %s, but got: %s %s %s", ex.Kind, ex.Severity, ex.Path, c.Kind, c.Severity, c.Path)
+				}
+			}
+		})
+	}
+}
+
+func parseIR(src string) (compiler.IR, error) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(src), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return compiler.ToIR([]*ast.Document{doc}), nil
+}
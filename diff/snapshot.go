@@ -0,0 +1,54 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gqlc/compiler"
+)
+
+// SnapshotOption is the generator option which names a snapshot of the
+// previous schema, written by compiler.WriteIR, e.g. {"previous": "schema.pb"}
+//
+const SnapshotOption = "previous"
+
+// WhatsChangedTitle is the title of the changelog section written by
+// WriteWhatsChanged.
+//
+const WhatsChangedTitle = "What's changed"
+
+// ReadSnapshot reads the previous schema named by the SnapshotOption. It
+// returns a nil IR, and no error, if the option isn't given.
+//
+func ReadSnapshot(opts compiler.Options) (compiler.IR, error) {
+	v, ok := opts[SnapshotOption]
+	if !ok {
+		return nil, nil
+	}
+
+	path, ok := v.(string)
+	if !ok || path == "" {
+		return nil, fmt.Errorf("diff: malformed options: %s must be a path", SnapshotOption)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("diff: failed to open snapshot: %s", err)
+	}
+	defer f.Close()
+
+	return compiler.ReadIR(f)
+}
+
+// WriteWhatsChanged writes a "What's changed" changelog section, see
+// WriteChangelog, describing the changes from the previous schema, so that
+// generated docs can highlight recent schema changes at the top. Nothing is
+// written if there isn't a previous schema.
+//
+func WriteWhatsChanged(w io.Writer, prev, ir compiler.IR) error {
+	if prev == nil {
+		return nil
+	}
+	return WriteChangelog(w, WhatsChangedTitle, Compare(prev, ir))
+}
@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gqlc/compiler"
+)
+
+func TestWriteWhatsChanged(t *testing.T) {
+	old, err := parseIR(`type User {
+	id: ID!
+	email: String
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	new, err := parseIR(`type User {
+	id: ID!
+	name: String
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	if err = compiler.WriteIR(&buf, old); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "schema.pb")
+	if err = ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		Name string
+		Opts compiler.Options
+		Ex   string
+		Err  bool
+	}{
+		{
+			Name: "NoSnapshot",
+			Opts: compiler.Options{},
+		},
+		{
+			Name: "Snapshot",
+			Opts: compiler.Options{SnapshotOption: path},
+			Ex: "## What's changed\n" +
+				"\n### Added\n\n" +
+				"- `User.name`: field was added\n" +
+				"\n### Removed\n\n" +
+				"- **Breaking:** `User.email`: field was removed\n",
+		},
+		{
+			Name: "MissingSnapshot",
+			Opts: compiler.Options{SnapshotOption: filepath.Join(dir, "missing.pb")},
+			Err:  true,
+		},
+		{
+			Name: "MalformedOption",
+			Opts: compiler.Options{SnapshotOption: true},
+			Err:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			prev, err := ReadSnapshot(testCase.Opts)
+			if testCase.Err {
+				if err == nil {
+					subT.Error("expected error reading snapshot")
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			var b bytes.Buffer
+			if err = WriteWhatsChanged(&b, prev, new); err != nil {
+				subT.Fatal(err)
+			}
+
+			if b.String() != testCase.Ex {
+				subT.Errorf("expected:\n%s\nbut got:\n%s", testCase.Ex, b.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// typeInfo is a flattened view of a type and all of its extensions.
+type typeInfo struct {
+	tok token.Token
+
+	// fields contains object and interface fields, schema root
+	// operations, and enum values.
+	fields map[string]*ast.Field
+
+	// args contains input object fields and directive arguments.
+	args map[string]*ast.InputValue
+
+	// members contains union members and implemented interfaces.
+	members map[string]bool
+
+	locs map[ast.DirectiveLocation_Loc]bool
+}
+
+func (t *typeInfo) kind() string {
+	switch t.tok {
+	case token.Token_TYPE:
+		return "object"
+	case token.Token_INPUT:
+		return "input object"
+	}
+	return strings.ToLower(t.tok.String())
+}
+
+func (t *typeInfo) path(name string) string {
+	if t.tok == token.Token_DIRECTIVE {
+		return "@" + name
+	}
+	return name
+}
+
+// flatten merges every type declaration and extension in the IR.
+func flatten(ir compiler.IR) map[string]*typeInfo {
+	types := make(map[string]*typeInfo)
+	for _, mdecls := range ir {
+		for name, decls := range mdecls {
+			for _, decl := range decls {
+				var ts *ast.TypeSpec
+				tok := decl.Tok
+				switch v := decl.Spec.(type) {
+				case *ast.TypeDecl_TypeSpec:
+					ts = v.TypeSpec
+				case *ast.TypeDecl_TypeExtSpec:
+					ts = v.TypeExtSpec.Type
+					tok = v.TypeExtSpec.Tok
+				}
+
+				t, ok := types[name]
+				if !ok {
+					t = &typeInfo{
+						tok:     tok,
+						fields:  make(map[string]*ast.Field),
+						args:    make(map[string]*ast.InputValue),
+						members: make(map[string]bool),
+						locs:    make(map[ast.DirectiveLocation_Loc]bool),
+					}
+					types[name] = t
+				}
+
+				addSpec(t, ts)
+			}
+		}
+	}
+	return types
+}
+
+func addSpec(t *typeInfo, ts *ast.TypeSpec) {
+	var fields *ast.FieldList
+	var args *ast.InputValueList
+	var members []*ast.Ident
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		fields = v.Schema.RootOps
+	case *ast.TypeSpec_Object:
+		fields = v.Object.Fields
+		members = v.Object.Interfaces
+	case *ast.TypeSpec_Interface:
+		fields = v.Interface.Fields
+	case *ast.TypeSpec_Union:
+		members = v.Union.Members
+	case *ast.TypeSpec_Enum:
+		fields = v.Enum.Values
+	case *ast.TypeSpec_Input:
+		args = v.Input.Fields
+	case *ast.TypeSpec_Directive:
+		args = v.Directive.Args
+		for _, l := range v.Directive.Locs {
+			t.locs[l.Loc] = true
+		}
+	}
+
+	if fields != nil {
+		for _, f := range fields.List {
+			t.fields[f.Name.Name] = f
+		}
+	}
+	if args != nil {
+		for _, a := range args.List {
+			t.args[a.Name.Name] = a
+		}
+	}
+	for _, m := range members {
+		t.members[m.Name] = true
+	}
+}
+
+func argMap(args *ast.InputValueList) map[string]*ast.InputValue {
+	m := make(map[string]*ast.InputValue)
+	if args == nil {
+		return m
+	}
+
+	for _, a := range args.List {
+		m[a.Name.Name] = a
+	}
+	return m
+}
+
+func fieldType(f *ast.Field) interface{} {
+	switch v := f.Type.(type) {
+	case *ast.Field_Ident:
+		return v.Ident
+	case *ast.Field_List:
+		return v.List
+	case *ast.Field_NonNull:
+		return v.NonNull
+	}
+	return nil
+}
+
+func inputType(v *ast.InputValue) interface{} {
+	switch x := v.Type.(type) {
+	case *ast.InputValue_Ident:
+		return x.Ident
+	case *ast.InputValue_List:
+		return x.List
+	case *ast.InputValue_NonNull:
+		return x.NonNull
+	}
+	return nil
+}
+
+func unwrapList(l *ast.List) interface{} {
+	switch v := l.Type.(type) {
+	case *ast.List_Ident:
+		return v.Ident
+	case *ast.List_List:
+		return v.List
+	case *ast.List_NonNull:
+		return v.NonNull
+	}
+	return nil
+}
+
+func unwrapNonNull(n *ast.NonNull) interface{} {
+	switch v := n.Type.(type) {
+	case *ast.NonNull_Ident:
+		return v.Ident
+	case *ast.NonNull_List:
+		return v.List
+	}
+	return nil
+}
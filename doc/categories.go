@@ -0,0 +1,148 @@
+package doc
+
+import (
+	"sort"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+)
+
+// Directive declares the @doc directive. It isn't registered with the
+// global registry, so generators which use it should register it with the
+// Registry of their compilation, see RegisterTypes.
+//
+var Directive = compiler.NewDirective("doc",
+	ast.DirectiveLocation_DOCUMENT,
+	ast.DirectiveLocation_SCALAR,
+	ast.DirectiveLocation_OBJECT,
+	ast.DirectiveLocation_INTERFACE,
+	ast.DirectiveLocation_UNION,
+	ast.DirectiveLocation_ENUM,
+	ast.DirectiveLocation_INPUT_OBJECT,
+).
+	Field("category", compiler.Named("String")).
+	Field("tags", compiler.List(compiler.NonNull("String"))).
+	Decl()
+
+// RegisterTypes registers the @doc directive with r, so documents which
+// apply it type check.
+//
+func RegisterTypes(r *compiler.Registry) {
+	r.RegisterTypes(Directive)
+}
+
+// TypeGroup represents a named group of types, e.g. a category or tag.
+type TypeGroup struct {
+	Name string
+
+	// Names of the types in the group, sorted
+	Types []string
+}
+
+// DocTags returns the category and tags given by an applied @doc directive,
+// which lets generators group types by domain, instead of only by kind:
+//
+// @doc(category: "Billing", tags: ["payments"])
+//
+func DocTags(dirs []*ast.DirectiveLit) (category string, tags []string) {
+	for _, d := range dirs {
+		if d.Name != "doc" || d.Args == nil {
+			continue
+		}
+
+		for _, arg := range d.Args.Args {
+			if arg.Name == nil {
+				continue
+			}
+
+			switch arg.Name.Name {
+			case "category":
+				if vals := compiler.ArgStrings(arg); len(vals) > 0 {
+					category = vals[0]
+				}
+			case "tags":
+				tags = append(tags, compiler.ArgStrings(arg)...)
+			}
+		}
+	}
+	return
+}
+
+// Categories groups the types of the IR by their @doc category, sorted by
+// name. Applying @doc to a Document sets the category of all of its types,
+// unless they set their own. Types without a category are left out.
+//
+func Categories(ir compiler.IR) []TypeGroup {
+	categories, _ := docGroups(ir)
+	return categories
+}
+
+// Tags groups the types of the IR by their @doc tags, sorted by name, e.g.
+// for rendering a tag index. Types are tagged with the tags applied to them,
+// as well as those applied to their Document.
+//
+func Tags(ir compiler.IR) []TypeGroup {
+	_, tags := docGroups(ir)
+	return tags
+}
+
+func docGroups(ir compiler.IR) (categories, tags []TypeGroup) {
+	cats := make(map[string]map[string]bool)
+	tagged := make(map[string]map[string]bool)
+	add := func(groups map[string]map[string]bool, group, name string) {
+		if groups[group] == nil {
+			groups[group] = make(map[string]bool)
+		}
+		groups[group][name] = true
+	}
+
+	for doc, types := range ir {
+		if compiler.IsBuiltins(doc) {
+			continue
+		}
+		docCategory, docTags := DocTags(doc.Directives)
+
+		for name, decls := range types {
+			category := docCategory
+			for _, decl := range decls {
+				ts := compiler.TypeSpecOf(decl)
+				if ts == nil {
+					continue
+				}
+
+				c, t := DocTags(ts.Directives)
+				if c != "" {
+					category = c
+				}
+				for _, tag := range t {
+					add(tagged, tag, name)
+				}
+			}
+
+			if category != "" {
+				add(cats, category, name)
+			}
+			for _, tag := range docTags {
+				add(tagged, tag, name)
+			}
+		}
+	}
+
+	return typeGroups(cats), typeGroups(tagged)
+}
+
+func typeGroups(groups map[string]map[string]bool) []TypeGroup {
+	l := make([]TypeGroup, 0, len(groups))
+	for group, names := range groups {
+		g := TypeGroup{Name: group}
+		for name := range names {
+			g.Types = append(g.Types, name)
+		}
+		sort.Strings(g.Types)
+
+		l = append(l, g)
+	}
+
+	sort.Slice(l, func(i, j int) bool { return l[i].Name < l[j].Name })
+	return l
+}
@@ -0,0 +1,81 @@
+package doc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/compiler/spec"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestCategories(t *testing.T) {
+	docs, err := parser.ParseDocs(token.NewDocSet(), map[string]io.Reader{
+		"billing": strings.NewReader(`@doc(category: "Billing", tags: ["payments"])
+
+type Invoice {
+	id: ID!
+}
+
+type Refund @doc(tags: ["support"]) {
+	id: ID!
+}
+
+scalar Money @doc(category: "Common")`),
+		"users": strings.NewReader(`type User @doc(category: "Accounts") {
+	id: ID!
+}
+
+extend type User @doc(tags: ["support"])
+
+type Query {
+	user: User
+}`),
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := compiler.ToIR(docs)
+
+	groups := func(l []TypeGroup) string { return fmt.Sprint(l) }
+
+	if c := groups(Categories(ir)); c != "[{Accounts [User]} {Billing [Invoice Refund]} {Common [Money]}]" {
+		t.Errorf("unexpected categories: %s", c)
+	}
+	if tags := groups(Tags(ir)); tags != "[{payments [Invoice Money Refund]} {support [Refund User]}]" {
+		t.Errorf("unexpected tags: %s", tags)
+	}
+}
+
+func TestRegisterTypes(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "billing", strings.NewReader(`type Invoice @doc(category: "Billing") {
+	id: ID!
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := compiler.ToIR([]*ast.Document{doc})
+
+	r := compiler.NewRegistry(compiler.GlobalRegistry())
+	errs, err := compiler.CheckTypesContext(compiler.WithRegistry(context.Background(), r), ir, 0, spec.NewValidator(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("expected @doc to be undefined without registering it")
+	}
+
+	RegisterTypes(r)
+	errs, err = compiler.CheckTypesContext(compiler.WithRegistry(context.Background(), r), ir, 0, spec.NewValidator(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+}
@@ -0,0 +1,56 @@
+// Package doc provides the schema queries and options which documentation
+// generators share, e.g. linking scalars to their specification or grouping
+// types by category, so each generator doesn't reimplement them.
+//
+package doc
+
+import "github.com/gqlc/graphql/ast"
+
+func fieldType(f *ast.Field) interface{} {
+	switch v := f.Type.(type) {
+	case *ast.Field_Ident:
+		return v.Ident
+	case *ast.Field_List:
+		return v.List
+	case *ast.Field_NonNull:
+		return v.NonNull
+	}
+	return nil
+}
+
+func inputType(v *ast.InputValue) interface{} {
+	switch x := v.Type.(type) {
+	case *ast.InputValue_Ident:
+		return x.Ident
+	case *ast.InputValue_List:
+		return x.List
+	case *ast.InputValue_NonNull:
+		return x.NonNull
+	}
+	return nil
+}
+
+// unwrapType returns the named type of a type reference.
+func unwrapType(t interface{}) *ast.Ident {
+	switch v := t.(type) {
+	case *ast.Ident:
+		return v
+	case *ast.List:
+		switch u := v.Type.(type) {
+		case *ast.List_Ident:
+			return u.Ident
+		case *ast.List_List:
+			return unwrapType(u.List)
+		case *ast.List_NonNull:
+			return unwrapType(u.NonNull)
+		}
+	case *ast.NonNull:
+		switch u := v.Type.(type) {
+		case *ast.NonNull_Ident:
+			return u.Ident
+		case *ast.NonNull_List:
+			return unwrapType(u.List)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package doc
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+)
+
+// InventoryHeader is the header row of an inventory, see WriteInventory.
+var InventoryHeader = []string{"document", "type", "kind", "field", "argument", "of_type", "nullable", "deprecated", "deprecation_reason"}
+
+// WriteInventory writes a flat inventory of the types, fields, arguments,
+// input fields and enum values of the IR as CSV, separated by comma, e.g.
+// ',' or '\t' for TSV, so that the API surface can be audited in a
+// spreadsheet. Every type, and every member of it, has its own row:
+//
+//	document,type,kind,field,argument,of_type,nullable,deprecated,deprecation_reason
+//	users.gql,User,type,,,,,false,
+//	users.gql,User,type,name,,String,true,true,Use fullName.
+//
+// Rows are sorted by type name, and then by declaration. Directives are
+// listed by their name, e.g. @auth, and builtin types are excluded.
+//
+func WriteInventory(w io.Writer, ir compiler.IR, comma rune) error {
+	type def struct {
+		doc  *ast.Document
+		decl *ast.TypeDecl
+	}
+
+	defs := make(map[string][]def)
+	var names []string
+	for _, doc := range ir.Documents() {
+		if compiler.IsBuiltins(doc) {
+			continue
+		}
+
+		for _, name := range compiler.TypeNames(ir[doc]) {
+			if defs[name] == nil {
+				names = append(names, name)
+			}
+			for _, decl := range ir[doc][name] {
+				defs[name] = append(defs[name], def{doc: doc, decl: decl})
+			}
+		}
+	}
+	sort.Strings(names)
+
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+	cw.Write(InventoryHeader)
+
+	for _, name := range names {
+		for _, d := range defs[name] {
+			ts := compiler.TypeSpecOf(d.decl)
+			if ts == nil {
+				continue
+			}
+
+			typeName := name
+			if _, ok := ts.Type.(*ast.TypeSpec_Directive); ok {
+				typeName = "@" + name
+			}
+
+			row := func(field, arg string, typ interface{}, dirs []*ast.DirectiveLit) {
+				reason, deprecated := compiler.DeprecationReason(dirs)
+
+				var ofType, nullable string
+				if typ != nil {
+					ofType = compiler.TypeString(typ)
+					_, nonNull := typ.(*ast.NonNull)
+					nullable = strconv.FormatBool(!nonNull)
+				}
+
+				cw.Write([]string{
+					d.doc.Name,
+					typeName,
+					strings.ToLower(compiler.DeclTok(d.decl).String()),
+					field,
+					arg,
+					ofType,
+					nullable,
+					strconv.FormatBool(deprecated),
+					reason,
+				})
+			}
+
+			if !compiler.IsExtension(d.decl) {
+				row("", "", nil, ts.Directives)
+			}
+			inventoryMembers(ts, row)
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func inventoryMembers(ts *ast.TypeSpec, row func(field, arg string, typ interface{}, dirs []*ast.DirectiveLit)) {
+	args := func(field string, l *ast.InputValueList) {
+		if l == nil {
+			return
+		}
+
+		for _, a := range l.List {
+			row(field, a.Name.Name, inputType(a), a.Directives)
+		}
+	}
+
+	var fields *ast.FieldList
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		fields = v.Schema.RootOps
+	case *ast.TypeSpec_Object:
+		fields = v.Object.Fields
+	case *ast.TypeSpec_Interface:
+		fields = v.Interface.Fields
+	case *ast.TypeSpec_Enum:
+		if v.Enum.Values != nil {
+			for _, val := range v.Enum.Values.List {
+				row(val.Name.Name, "", nil, val.Directives)
+			}
+		}
+	case *ast.TypeSpec_Input:
+		if v.Input.Fields != nil {
+			for _, f := range v.Input.Fields.List {
+				row(f.Name.Name, "", inputType(f), f.Directives)
+			}
+		}
+	case *ast.TypeSpec_Directive:
+		args("", v.Directive.Args)
+	}
+
+	if fields == nil {
+		return
+	}
+	for _, f := range fields.List {
+		row(f.Name.Name, "", fieldType(f), f.Directives)
+		args(f.Name.Name, f.Args)
+	}
+}
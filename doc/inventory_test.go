@@ -0,0 +1,85 @@
+package doc
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestWriteInventory(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "users.gql", strings.NewReader(`type User {
+	id: ID!
+	name: String @deprecated(reason: "Use fullName, or firstName.")
+	friends(first: Int = 10, after: String!): [User!]!
+}
+
+enum Role {
+	ADMIN
+	GUEST @deprecated
+}
+
+input Filter {
+	role: Role
+}
+
+directive @auth(role: Role!) on FIELD_DEFINITION
+
+extend type User {
+	fullName: String
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := compiler.ToIR([]*ast.Document{doc})
+
+	testCases := []struct {
+		Name  string
+		Comma rune
+		Ex    string
+	}{
+		{
+			Name:  "CSV",
+			Comma: ',',
+			Ex: `document,type,kind,field,argument,of_type,nullable,deprecated,deprecation_reason
+users.gql,Filter,input,,,,,false,
+users.gql,Filter,input,role,,Role,true,false,
+users.gql,Role,enum,,,,,false,
+users.gql,Role,enum,ADMIN,,,,false,
+users.gql,Role,enum,GUEST,,,,true,No longer supported
+users.gql,User,type,,,,,false,
+users.gql,User,type,id,,ID!,false,false,
+users.gql,User,type,name,,String,true,true,"Use fullName, or firstName."
+users.gql,User,type,friends,,[User!]!,false,false,
+users.gql,User,type,friends,first,Int,true,false,
+users.gql,User,type,friends,after,String!,false,false,
+users.gql,User,type,fullName,,String,true,false,
+users.gql,@auth,directive,,,,,false,
+users.gql,@auth,directive,,role,Role!,false,false,
+`,
+		},
+		{
+			Name:  "TSV",
+			Comma: '\t',
+			Ex: "document\ttype\tkind\tfield\targument\tof_type\tnullable\tdeprecated\tdeprecation_reason\n" +
+				"users.gql\tFilter\tinput\t\t\t\t\tfalse\t\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			var b bytes.Buffer
+			if err := WriteInventory(&b, ir, testCase.Comma); err != nil {
+				subT.Fatal(err)
+			}
+
+			if !strings.HasPrefix(b.String(), testCase.Ex) {
+				subT.Errorf("expected inventory:\n%s\nbut got:\n%s", testCase.Ex, b.String())
+			}
+		})
+	}
+}
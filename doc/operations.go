@@ -0,0 +1,115 @@
+package doc
+
+import (
+	"strings"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// Operation represents a field of a root operation type, i.e. a query,
+// mutation or subscription which clients can invoke.
+//
+type Operation struct {
+	// Op is the operation the field belongs to: query, mutation or subscription
+	Op string
+
+	// Type is the name of the root operation type
+	Type string
+
+	// Doc is the Document which declares the field
+	Doc *ast.Document
+
+	Field *ast.Field
+}
+
+// Operations returns the fields of the root operation types, see
+// compiler.RootOperations, so that they can be documented as first-class entries
+// instead of as members of their root type. Operations are ordered by
+// query, mutation and subscription, and then by declaration, including
+// fields declared by extensions.
+//
+func Operations(ir compiler.IR) (ops []Operation) {
+	roots := compiler.RootOperations(ir)
+
+	for _, op := range []string{"query", "mutation", "subscription"} {
+		for name, rop := range roots {
+			if rop != op {
+				continue
+			}
+
+			for _, doc := range ir.Documents() {
+				for _, decl := range ir[doc][name] {
+					obj, ok := compiler.TypeSpecOf(decl).Type.(*ast.TypeSpec_Object)
+					if !ok || obj.Object.Fields == nil {
+						continue
+					}
+
+					for _, f := range obj.Object.Fields.List {
+						ops = append(ops, Operation{Op: op, Type: name, Doc: doc, Field: f})
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// ExampleOperation returns an example invocation of an Operation, e.g.
+//
+//	query user($id: ID!) {
+//		user(id: $id) {
+//			__typename
+//		}
+//	}
+//
+// Arguments are passed as variables, and composite return types, which
+// require a selection, select __typename.
+//
+func ExampleOperation(ir compiler.IR, op Operation) string {
+	var b strings.Builder
+
+	b.WriteString(op.Op + " " + op.Field.Name.Name)
+
+	var vars, args []string
+	if op.Field.Args != nil {
+		for _, a := range op.Field.Args.List {
+			vars = append(vars, "$"+a.Name.Name+": "+compiler.TypeString(inputType(a)))
+			args = append(args, a.Name.Name+": $"+a.Name.Name)
+		}
+	}
+	if len(vars) > 0 {
+		b.WriteString("(" + strings.Join(vars, ", ") + ")")
+	}
+
+	b.WriteString(" {\n\t" + op.Field.Name.Name)
+	if len(args) > 0 {
+		b.WriteString("(" + strings.Join(args, ", ") + ")")
+	}
+
+	if isComposite(ir, unwrapType(fieldType(op.Field))) {
+		b.WriteString(" {\n\t\t__typename\n\t}")
+	}
+	b.WriteString("\n}\n")
+	return b.String()
+}
+
+// isComposite reports whether the named type is an object, interface
+// or union, which must have a selection set when queried.
+//
+func isComposite(ir compiler.IR, id *ast.Ident) bool {
+	if id == nil {
+		return false
+	}
+
+	for _, types := range ir {
+		for _, decl := range types[id.Name] {
+			switch compiler.DeclTok(decl) {
+			case token.Token_TYPE, token.Token_INTERFACE, token.Token_UNION:
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package doc
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestOperations(t *testing.T) {
+	docs, err := parser.ParseDocs(token.NewDocSet(), map[string]io.Reader{
+		"a": strings.NewReader(`type Mutation {
+	deleteUser(id: ID!): Boolean
+}
+
+type Query {
+	user(id: ID!, first: Int = 1): User
+	version: String
+}
+
+type User {
+	id: ID!
+}`),
+		"b": strings.NewReader(`extend type Query {
+	users: [User!]
+}`),
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := compiler.ToIR(docs)
+
+	testCases := []struct {
+		Name    string
+		Op      string
+		Doc     string
+		Example string
+	}{
+		{
+			Name: "user",
+			Op:   "query",
+			Doc:  "a",
+			Example: `query user($id: ID!, $first: Int) {
+	user(id: $id, first: $first) {
+		__typename
+	}
+}
+`,
+		},
+		{
+			Name: "version",
+			Op:   "query",
+			Doc:  "a",
+			Example: `query version {
+	version
+}
+`,
+		},
+		{
+			Name: "users",
+			Op:   "query",
+			Doc:  "b",
+			Example: `query users {
+	users {
+		__typename
+	}
+}
+`,
+		},
+		{
+			Name: "deleteUser",
+			Op:   "mutation",
+			Doc:  "a",
+			Example: `mutation deleteUser($id: ID!) {
+	deleteUser(id: $id)
+}
+`,
+		},
+	}
+
+	ops := Operations(ir)
+	if len(ops) != len(testCases) {
+		t.Fatalf("expected %d operations but got: %d", len(testCases), len(ops))
+	}
+
+	for i, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			op := ops[i]
+			if op.Field.Name.Name != testCase.Name || op.Op != testCase.Op || op.Doc.Name != testCase.Doc {
+				subT.Fatalf("expected %s %s from %s but got: %s %s from %s", testCase.Op, testCase.Name, testCase.Doc, op.Op, op.Field.Name.Name, op.Doc.Name)
+			}
+
+			if ex := ExampleOperation(ir, op); ex != testCase.Example {
+				subT.Errorf("expected example:\n%s\nbut got:\n%s", testCase.Example, ex)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package doc
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SanitizeOption is the generator option which selects how raw HTML in
+// descriptions is sanitized, e.g. {"sanitize": "strip"}, see ParseSanitizer.
+//
+const SanitizeOption = "sanitize"
+
+// Sanitizer represents how raw HTML in descriptions is handled when they're
+// rendered as HTML, since otherwise schema authors could inject arbitrary
+// markup, or scripts, into generated docs.
+//
+type Sanitizer uint8
+
+const (
+	// SanitizeEscape escapes raw HTML, so that it's rendered as text.
+	// It's the default.
+	SanitizeEscape Sanitizer = iota
+
+	// SanitizeStrip removes raw HTML tags, along with the contents of
+	// script and style elements. Anything left which could still form
+	// markup, e.g. an unclosed tag, is escaped.
+	SanitizeStrip
+
+	// SanitizeNone leaves raw HTML as is, for trusted schemas.
+	SanitizeNone
+)
+
+var sanitizerNames = [...]string{
+	SanitizeEscape: "escape",
+	SanitizeStrip:  "strip",
+	SanitizeNone:   "none",
+}
+
+// String returns the name of the Sanitizer, e.g. escape.
+func (s Sanitizer) String() string {
+	if int(s) < len(sanitizerNames) {
+		return sanitizerNames[s]
+	}
+	return fmt.Sprintf("Sanitizer(%d)", int(s))
+}
+
+// ParseSanitizer returns the Sanitizer with the given name: escape, strip
+// or none. Names are case insensitive, and an empty name is SanitizeEscape.
+//
+func ParseSanitizer(s string) (Sanitizer, error) {
+	if s == "" {
+		return SanitizeEscape, nil
+	}
+
+	for i, name := range sanitizerNames {
+		if strings.EqualFold(name, s) {
+			return Sanitizer(i), nil
+		}
+	}
+	return 0, fmt.Errorf("doc: unknown sanitizer: %s", s)
+}
+
+var (
+	htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+	scriptElem = regexp.MustCompile(`(?is)<script\b.*?(</script\s*>|$)`)
+	styleElem  = regexp.MustCompile(`(?is)<style\b.*?(</style\s*>|$)`)
+	htmlTag    = regexp.MustCompile(`(?s)<(/?[a-zA-Z][^>]*|!--.*?--)>`)
+)
+
+// Sanitize returns the description text with its raw HTML handled by s.
+func (s Sanitizer) Sanitize(text string) string {
+	switch s {
+	case SanitizeEscape:
+		return htmlEscaper.Replace(text)
+	case SanitizeStrip:
+		text = scriptElem.ReplaceAllString(text, "")
+		text = styleElem.ReplaceAllString(text, "")
+		return htmlEscaper.Replace(htmlTag.ReplaceAllString(text, ""))
+	}
+	return text
+}
@@ -0,0 +1,63 @@
+package doc
+
+import "testing"
+
+func TestSanitizer(t *testing.T) {
+	testCases := []struct {
+		Name      string
+		Sanitizer string
+		Text      string
+		Ex        string
+	}{
+		{
+			Name: "DefaultEscapes",
+			Text: `A <b>bold</b> & <script>alert("hi")</script> user.`,
+			Ex:   `A &lt;b&gt;bold&lt;/b&gt; &amp; &lt;script&gt;alert("hi")&lt;/script&gt; user.`,
+		},
+		{
+			Name:      "Strip",
+			Sanitizer: "strip",
+			Text: `A <b>bold</b> user.<SCRIPT type="text/javascript">
+alert("hi")
+</script><style>b { color: red }</style><!-- hidden -->
+Compare with a < b, and <img src=x onerror="alert(1)">.`,
+			Ex: `A bold user.
+Compare with a &lt; b, and .`,
+		},
+		{
+			Name:      "StripUnclosedTag",
+			Sanitizer: "strip",
+			Text:      `A user.<img src=x onerror=alert(1) x=`,
+			Ex:        `A user.&lt;img src=x onerror=alert(1) x=`,
+		},
+		{
+			Name:      "StripUnclosedScript",
+			Sanitizer: "Strip",
+			Text:      `A user.<script>alert("hi")`,
+			Ex:        `A user.`,
+		},
+		{
+			Name:      "None",
+			Sanitizer: "none",
+			Text:      `A <b>bold</b> user.`,
+			Ex:        `A <b>bold</b> user.`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			s, err := ParseSanitizer(testCase.Sanitizer)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if out := s.Sanitize(testCase.Text); out != testCase.Ex {
+				subT.Errorf("expected:\n%s\nbut got:\n%s", testCase.Ex, out)
+			}
+		})
+	}
+
+	if _, err := ParseSanitizer("html"); err == nil {
+		t.Error("expected error for unknown sanitizer")
+	}
+}
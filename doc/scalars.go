@@ -0,0 +1,81 @@
+package doc
+
+import (
+	"fmt"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// ScalarDocsOption is the generator option which maps scalar names to the
+// URL of their documentation, e.g. {"scalarDocs": {"DateTime": "https://..."}}
+//
+const ScalarDocsOption = "scalarDocs"
+
+// SpecifiedBy returns the URL given by an applied @specifiedBy directive,
+// and whether the directive was applied at all.
+//
+func SpecifiedBy(dirs []*ast.DirectiveLit) (url string, ok bool) {
+	for _, d := range dirs {
+		if d.Name != "specifiedBy" {
+			continue
+		}
+
+		if d.Args != nil {
+			for _, arg := range d.Args.Args {
+				if arg.Name == nil || arg.Name.Name != "url" {
+					continue
+				}
+
+				if vals := compiler.ArgStrings(arg); len(vals) > 0 {
+					url = vals[0]
+				}
+			}
+		}
+		return url, true
+	}
+	return "", false
+}
+
+// ScalarDocs returns the URL documenting each scalar, keyed by name, so
+// that generators can link references to custom scalars to their
+// specification instead of a local anchor. URLs are taken from the
+// scalars' @specifiedBy directives and the ScalarDocsOption, which takes
+// precedence.
+//
+func ScalarDocs(ir compiler.IR, opts compiler.Options) (map[string]string, error) {
+	docs := make(map[string]string)
+	for _, types := range ir {
+		for name, decls := range types {
+			for _, decl := range decls {
+				if compiler.DeclTok(decl) != token.Token_SCALAR {
+					continue
+				}
+
+				if url, ok := SpecifiedBy(compiler.TypeSpecOf(decl).Directives); ok && url != "" {
+					docs[name] = url
+				}
+			}
+		}
+	}
+
+	v, ok := opts[ScalarDocsOption]
+	if !ok {
+		return docs, nil
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("doc: malformed options: %s must be an object of scalar names to urls", ScalarDocsOption)
+	}
+	for name, u := range m {
+		url, ok := u.(string)
+		if !ok {
+			return nil, fmt.Errorf("doc: malformed options: %s: %s must be a url", ScalarDocsOption, name)
+		}
+
+		docs[name] = url
+	}
+	return docs, nil
+}
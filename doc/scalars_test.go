@@ -0,0 +1,90 @@
+package doc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestScalarDocs(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`scalar DateTime @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")
+
+scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")
+
+scalar Money
+
+type Query {
+	now: DateTime
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := compiler.ToIR([]*ast.Document{doc})
+
+	testCases := []struct {
+		Name string
+		Opts string
+		Docs map[string]string
+		Err  bool
+	}{
+		{
+			Name: "SpecifiedBy",
+			Docs: map[string]string{
+				"DateTime": "https://tools.ietf.org/html/rfc3339",
+				"UUID":     "https://tools.ietf.org/html/rfc4122",
+			},
+		},
+		{
+			Name: "Option",
+			Opts: `{"scalarDocs": {"Money": "https://example.com/money", "UUID": "https://example.com/uuid"}}`,
+			Docs: map[string]string{
+				"DateTime": "https://tools.ietf.org/html/rfc3339",
+				"UUID":     "https://example.com/uuid",
+				"Money":    "https://example.com/money",
+			},
+		},
+		{
+			Name: "MalformedOption",
+			Opts: `{"scalarDocs": "https://example.com"}`,
+			Err:  true,
+		},
+		{
+			Name: "MalformedURL",
+			Opts: `{"scalarDocs": {"Money": 1}}`,
+			Err:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			opts, err := compiler.ParseOptions(testCase.Opts)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			docs, err := ScalarDocs(ir, opts)
+			if testCase.Err {
+				if err == nil {
+					subT.Error("expected error for malformed option")
+				}
+				return
+			}
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if len(docs) != len(testCase.Docs) {
+				subT.Fatalf("expected scalar docs: %v but got: %v", testCase.Docs, docs)
+			}
+			for name, url := range testCase.Docs {
+				if docs[name] != url {
+					subT.Errorf("expected %s to link to: %s but got: %s", name, url, docs[name])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package doc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SlugsOption is the generator option which selects how heading anchors are
+// generated, e.g. {"slugs": "gitlab"}, see ParseSlugStrategy.
+//
+const SlugsOption = "slugs"
+
+// SlugStrategy represents how a markdown renderer derives the anchor of a
+// heading, so that links between headings resolve on the platform the
+// generated docs are viewed on.
+//
+type SlugStrategy uint8
+
+const (
+	// SlugGitHub lower cases the heading, removes punctuation other than
+	// hyphens and underscores, and replaces each space with a hyphen.
+	// It's the default.
+	SlugGitHub SlugStrategy = iota
+
+	// SlugGitLab is like SlugGitHub, but also collapses consecutive
+	// hyphens into one.
+	SlugGitLab
+
+	// SlugPlain lower cases the heading and replaces each run of
+	// whitespace with a hyphen, keeping any punctuation.
+	SlugPlain
+)
+
+var slugNames = [...]string{
+	SlugGitHub: "github",
+	SlugGitLab: "gitlab",
+	SlugPlain:  "plain",
+}
+
+// String returns the name of the SlugStrategy, e.g. github.
+func (s SlugStrategy) String() string {
+	if int(s) < len(slugNames) {
+		return slugNames[s]
+	}
+	return fmt.Sprintf("SlugStrategy(%d)", int(s))
+}
+
+// ParseSlugStrategy returns the SlugStrategy with the given name: github,
+// gitlab or plain. Names are case insensitive, and an empty name is
+// SlugGitHub.
+//
+func ParseSlugStrategy(s string) (SlugStrategy, error) {
+	if s == "" {
+		return SlugGitHub, nil
+	}
+
+	for i, name := range slugNames {
+		if strings.EqualFold(name, s) {
+			return SlugStrategy(i), nil
+		}
+	}
+	return 0, fmt.Errorf("doc: unknown slug strategy: %s", s)
+}
+
+// Slug returns the anchor of a heading, without de-duplicating it, see Slugger.
+func (s SlugStrategy) Slug(heading string) string {
+	heading = strings.ToLower(strings.TrimSpace(heading))
+
+	var b strings.Builder
+	switch s {
+	case SlugPlain:
+		return strings.Join(strings.Fields(heading), "-")
+	case SlugGitHub, SlugGitLab:
+		for _, r := range heading {
+			switch {
+			case r == ' ':
+				r = '-'
+			case r == '-', r == '_', unicode.IsLetter(r), unicode.IsDigit(r):
+			default:
+				continue
+			}
+
+			if s == SlugGitLab && r == '-' && strings.HasSuffix(b.String(), "-") {
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Slugger generates unique heading anchors within a single document. Repeated
+// anchors are suffixed with -1, -2 and so on, as the markdown renderers do.
+//
+type Slugger struct {
+	strategy SlugStrategy
+	seen     map[string]int
+}
+
+// NewSlugger returns a Slugger which uses the given SlugStrategy.
+func NewSlugger(s SlugStrategy) *Slugger {
+	return &Slugger{strategy: s, seen: make(map[string]int)}
+}
+
+// Slug returns the unique anchor of a heading.
+func (s *Slugger) Slug(heading string) string {
+	slug := s.strategy.Slug(heading)
+
+	n, ok := s.seen[slug]
+	s.seen[slug] = n + 1
+	if !ok {
+		return slug
+	}
+
+	for {
+		unique := slug + "-" + strconv.Itoa(n)
+		if _, ok := s.seen[unique]; !ok {
+			s.seen[unique] = 1
+			return unique
+		}
+		n++
+		s.seen[slug] = n + 1
+	}
+}
@@ -0,0 +1,48 @@
+package doc
+
+import "testing"
+
+func TestSlugger(t *testing.T) {
+	headings := []string{"User", "Query.user(id: ID!)", "Snake_case -- Fields", "User", "User-1", "User"}
+
+	testCases := []struct {
+		Name     string
+		Strategy string
+		Slugs    []string
+	}{
+		{
+			Name:  "DefaultGitHub",
+			Slugs: []string{"user", "queryuserid-id", "snake_case----fields", "user-1", "user-1-1", "user-2"},
+		},
+		{
+			Name:     "GitLab",
+			Strategy: "gitlab",
+			Slugs:    []string{"user", "queryuserid-id", "snake_case-fields", "user-1", "user-1-1", "user-2"},
+		},
+		{
+			Name:     "Plain",
+			Strategy: "Plain",
+			Slugs:    []string{"user", "query.user(id:-id!)", "snake_case----fields", "user-1", "user-1-1", "user-2"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			strategy, err := ParseSlugStrategy(testCase.Strategy)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			s := NewSlugger(strategy)
+			for i, h := range headings {
+				if slug := s.Slug(h); slug != testCase.Slugs[i] {
+					subT.Errorf("expected %q to be slugged as: %s but got: %s", h, testCase.Slugs[i], slug)
+				}
+			}
+		})
+	}
+
+	if _, err := ParseSlugStrategy("bitbucket"); err == nil {
+		t.Error("expected error for unknown slug strategy")
+	}
+}
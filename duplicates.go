@@ -0,0 +1,168 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// DuplicateError represents a type, or directive, which is defined more
+// than once by the Documents which make up a schema.
+//
+type DuplicateError struct {
+	// Name of the type
+	Type string
+
+	// Definitions of the type, in Document order
+	Defs []Position
+
+	// Override is true when a registered type, e.g. String, is redefined
+	// by a single Document with the same kind, which is assumed to be
+	// intentional. Overrides are reported as SeverityInfo.
+	Override bool
+}
+
+// Error returns a string representation of a DuplicateError.
+func (e *DuplicateError) Error() string {
+	docs := make([]string, 0, len(e.Defs))
+	for _, def := range e.Defs {
+		docs = append(docs, def.Doc)
+	}
+
+	if e.Override {
+		return fmt.Sprintf("compiler: %s overrides registered type in: %s", e.Type, docs[len(docs)-1])
+	}
+	return fmt.Sprintf("compiler: %s is defined more than once in: %s", e.Type, strings.Join(docs, ", "))
+}
+
+// Code returns the Diagnostic code of a DuplicateError: "duplicate".
+func (e *DuplicateError) Code() string { return "duplicate" }
+
+// Level returns the severity of a DuplicateError, which is SeverityInfo
+// for overrides and otherwise SeverityError.
+//
+func (e *DuplicateError) Level() Severity {
+	if e.Override {
+		return SeverityInfo
+	}
+	return SeverityError
+}
+
+// Position returns the last definition of the type.
+func (e *DuplicateError) Position() Position { return e.Defs[len(e.Defs)-1] }
+
+// Unwrap always returns nil, since a DuplicateError doesn't wrap an error.
+func (e *DuplicateError) Unwrap() error { return nil }
+
+// NewDuplicateError returns a DuplicateError for the named type if the
+// Document holds more than one definition of it, or nil. Unlike
+// DuplicateValidator, it can be used once imports have been reduced, e.g.
+// by spec.Validator, but only for definitions within the Document.
+//
+func NewDuplicateError(ir IR, doc *ast.Document, name string) *DuplicateError {
+	var defs []Position
+	for _, decl := range ir[doc][name] {
+		if _, ok := decl.Spec.(*ast.TypeDecl_TypeSpec); !ok {
+			continue
+		}
+
+		defs = append(defs, Position{Doc: doc.Name, Type: name, Pos: decl.TokPos})
+	}
+
+	if len(defs) < 2 {
+		return nil
+	}
+	return &DuplicateError{Type: name, Defs: defs}
+}
+
+// DuplicateValidator reports every type which is defined more than once
+// within a Document, or across a Document and the Documents it imports,
+// directly or indirectly, as a DuplicateError. Registered types count as
+// imported by every Document.
+//
+// It must be run before ReduceImports, since Documents only keep track
+// of where their types are defined until their imports are reduced.
+//
+var DuplicateValidator = TypeCheckerFn(validateDuplicates)
+
+func validateDuplicates(ir IR) (errs []error) {
+	docMap := make(map[string]*ast.Document, len(ir))
+	for doc := range ir {
+		docMap[doc.Name] = doc
+	}
+
+	reported := make(map[string]bool)
+	for _, doc := range ir.Documents() {
+		if doc == builtins {
+			continue
+		}
+
+		scope := importScope(doc, docMap)
+		if _, ok := ir[builtins]; ok {
+			scope = append([]*ast.Document{builtins}, scope...)
+		}
+
+		defs := make(map[string][]Position)
+		decls := make(map[string][]*ast.TypeDecl)
+		registered := make(map[string]bool)
+		var names []string
+		for _, d := range scope {
+			for _, name := range TypeNames(ir[d]) {
+				for _, decl := range ir[d][name] {
+					if _, ok := decl.Spec.(*ast.TypeDecl_TypeSpec); !ok {
+						continue
+					}
+
+					if defs[name] == nil {
+						names = append(names, name)
+					}
+					defs[name] = append(defs[name], Position{Doc: d.Name, Type: name, Pos: decl.TokPos})
+					decls[name] = append(decls[name], decl)
+					registered[name] = registered[name] || d == builtins
+				}
+			}
+		}
+
+		for _, name := range names {
+			if len(defs[name]) < 2 {
+				continue
+			}
+
+			key := fmt.Sprint(defs[name])
+			if reported[key] {
+				continue
+			}
+			reported[key] = true
+
+			d := decls[name]
+			errs = append(errs, &DuplicateError{
+				Type:     name,
+				Defs:     defs[name],
+				Override: registered[name] && len(d) == 2 && DeclTok(d[0]) == DeclTok(d[1]),
+			})
+		}
+	}
+	return
+}
+
+// importScope returns the Document along with every Document it imports,
+// directly or indirectly, in import order.
+//
+func importScope(doc *ast.Document, docMap map[string]*ast.Document) (scope []*ast.Document) {
+	visited := make(map[*ast.Document]bool)
+	var visit func(d *ast.Document)
+	visit = func(d *ast.Document) {
+		if d == nil || visited[d] {
+			return
+		}
+		visited[d] = true
+		scope = append(scope, d)
+
+		for _, imp := range DocImports(d) {
+			visit(docMap[imp.Path])
+		}
+	}
+	visit(doc)
+	return
+}
@@ -0,0 +1,112 @@
+package compiler
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestDuplicateValidator(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Docs map[string]string
+		Errs []string
+		Sevs []Severity
+	}{
+		{
+			Name: "Unrelated",
+			Docs: map[string]string{
+				"a": `type User { id: ID }`,
+				"b": `type User { id: ID }`,
+			},
+		},
+		{
+			Name: "Extension",
+			Docs: map[string]string{
+				"a": `type User { id: ID }
+extend type User { name: String }`,
+			},
+		},
+		{
+			Name: "SameDocument",
+			Docs: map[string]string{
+				"a": `type User { id: ID }
+type User { name: String }`,
+			},
+			Errs: []string{"compiler: User is defined more than once in: a, a"},
+			Sevs: []Severity{SeverityError},
+		},
+		{
+			Name: "Imported",
+			Docs: map[string]string{
+				"a": `@import(paths: ["b"])
+type User { id: ID }`,
+				"b": `@import(paths: ["c"])
+type Query { a: Int }`,
+				"c": `type User { id: ID }`,
+			},
+			Errs: []string{"compiler: User is defined more than once in: a, c"},
+			Sevs: []Severity{SeverityError},
+		},
+		{
+			Name: "Override",
+			Docs: map[string]string{
+				"a": `scalar String`,
+			},
+			Errs: []string{"compiler: String overrides registered type in: a"},
+			Sevs: []Severity{SeverityInfo},
+		},
+		{
+			Name: "RegisteredCollision",
+			Docs: map[string]string{
+				"a": `type String { id: ID }`,
+			},
+			Errs: []string{"compiler: String is defined more than once in: gqlc.compiler.types, a"},
+			Sevs: []Severity{SeverityError},
+		},
+	}
+
+	reg := NewRegistry(nil)
+	reg.RegisterTypes(NewScalar("String").Decl())
+	ctx := WithRegistry(context.Background(), reg)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			readers := make(map[string]io.Reader, len(testCase.Docs))
+			for name, src := range testCase.Docs {
+				readers[name] = strings.NewReader(src)
+			}
+
+			docs, err := parser.ParseDocs(token.NewDocSet(), readers, 0)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			errs, err := CheckTypesContext(ctx, ToIR(docs), 0, DuplicateValidator)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if len(errs) != len(testCase.Errs) {
+				subT.Fatalf("expected errors: %v but got: %v", testCase.Errs, errs)
+			}
+			for i, err := range errs {
+				if err.Error() != testCase.Errs[i] {
+					subT.Errorf("expected error: %s but got: %s", testCase.Errs[i], err)
+				}
+				if SeverityOf(err) != testCase.Sevs[i] {
+					subT.Errorf("expected severity: %s but got: %s", testCase.Sevs[i], SeverityOf(err))
+				}
+
+				d := err.(*DuplicateError)
+				if p := d.Position(); p.Pos == 0 {
+					subT.Errorf("expected position of last definition but got: %v", p)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Diagnostic is implemented by the errors of the compiler package, i.e.
+// TypeError, ImportError, MergeError and DuplicateError, so callers can
+// handle them uniformly, e.g. with errors.As. Errors reported by other
+// packages, e.g. spec.Validator, lint and diff, or by LockFile.Verify may
+// be plain errors, whose severity is given by SeverityOf.
+//
+type Diagnostic interface {
+	error
+
+	// Code identifies the kind of Diagnostic, e.g. "type" or "import".
+	Code() string
+
+	// Level returns the severity of the Diagnostic.
+	Level() Severity
+
+	// Position returns where the Diagnostic was found.
+	Position() Position
+
+	// Unwrap returns the underlying error, if any.
+	Unwrap() error
+}
+
+// Position locates a Diagnostic within the compiled Documents.
+// Either field may be empty when it isn't known.
+//
+type Position struct {
+	// Name of the Document
+	Doc string
+
+	// Name of the type
+	Type string
+
+	// Pos of the type declaration, which can be resolved to a line and
+	// column with the token.DocSet the Document was parsed with. It is
+	// zero when it isn't known.
+	Pos int64
+}
+
+// String returns the position as doc:Type, or whichever part is known.
+func (p Position) String() string {
+	switch {
+	case p.Doc == "":
+		return p.Type
+	case p.Type == "":
+		return p.Doc
+	}
+	return p.Doc + ":" + p.Type
+}
+
+// MultiError aggregates several errors into one, e.g. the errors
+// returned by CheckTypes. errors.Is and errors.As match against
+// each of the aggregated errors.
+//
+type MultiError []error
+
+// Error returns a string representation of a MultiError, with one
+// error per line.
+//
+func (m MultiError) Error() string {
+	if len(m) == 1 {
+		return m[0].Error()
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "compiler: %d errors occurred:", len(m))
+	for _, err := range m {
+		b.WriteString("\n\t* ")
+		b.WriteString(err.Error())
+	}
+	return b.String()
+}
+
+// ErrorOrNil returns the MultiError as an error, or nil if it's empty.
+func (m MultiError) ErrorOrNil() error {
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
+
+// Is reports whether any of the aggregated errors matches target.
+func (m MultiError) Is(target error) bool {
+	for _, err := range m {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first aggregated error which matches target.
+func (m MultiError) As(target interface{}) bool {
+	for _, err := range m {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapMsg appends the wrapped error, if any, to an error message.
+func wrapMsg(msg string, err error) string {
+	if err == nil {
+		return msg
+	}
+	return msg + ": " + err.Error()
+}
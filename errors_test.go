@@ -0,0 +1,51 @@
+package compiler
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+var (
+	_ Diagnostic = &TypeError{}
+	_ Diagnostic = &ImportError{}
+	_ Diagnostic = &MergeError{}
+)
+
+func TestMultiError(t *testing.T) {
+	doc := &ast.Document{Name: "test"}
+
+	typeErr := &TypeError{Doc: doc, Msg: "undefined type: A"}
+	importErr := &ImportError{Doc: doc, Msg: "unknown import: \"b\"", Err: io.EOF}
+	mergeErr := &MergeError{Type: "A", Msg: "duplicate field: a"}
+
+	errs := MultiError{typeErr, importErr, mergeErr}
+
+	expected := `compiler: 3 errors occurred:
+	* compiler: encountered type error in test:undefined type: A
+	* compiler: import error encountered in test:unknown import: "b": EOF
+	* compiler: merge error encountered in A: duplicate field: a`
+	if errs.Error() != expected {
+		t.Errorf("expected: %s\nbut got: %s", expected, errs)
+	}
+
+	if !errors.Is(errs, io.EOF) {
+		t.Error("expected wrapped error to match")
+	}
+
+	var merr *MergeError
+	if !errors.As(errs, &merr) || merr != mergeErr {
+		t.Errorf("expected merge error but got: %v", merr)
+	}
+
+	var diag Diagnostic
+	if !errors.As(errs, &diag) || diag.Code() != "type" || diag.Position().String() != "test" {
+		t.Errorf("expected type diagnostic but got: %v", diag)
+	}
+
+	if MultiError(nil).ErrorOrNil() != nil {
+		t.Error("expected empty MultiError to be nil")
+	}
+}
@@ -0,0 +1,66 @@
+package compiler
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/gqlc/graphql/ast"
+)
+
+// Flatten returns a self-contained copy of every Document in the IR: its
+// imports, direct and indirect, are resolved, as ReduceImports does, and
+// its type extensions are merged, as MergeExtensions does. This is useful
+// for feeding tools which don't understand @import, e.g.
+//
+//	flat, err := compiler.Flatten(ir)
+//	if err != nil {
+//		return err
+//	}
+//	for _, doc := range compiler.FromIR(flat) {
+//		compiler.WriteSDL(w, doc)
+//	}
+//
+// The IR itself is left untouched. Any MergeErrors are returned together,
+// as a MultiError, along with the flattened Documents, which may then
+// still hold extensions that couldn't be merged.
+//
+func Flatten(ir IR) (IR, error) {
+	docMap := make(map[string]*ast.Document, len(ir))
+	for doc := range ir {
+		docMap[doc.Name] = doc
+	}
+
+	var errs MultiError
+	flat := make(IR, len(ir))
+	for _, doc := range ir.Documents() {
+		if IsBuiltins(doc) {
+			continue
+		}
+
+		scope := make(IR)
+		for _, d := range importScope(doc, docMap) {
+			cdoc := proto.Clone(d).(*ast.Document)
+			cdoc.Types = nil
+
+			types := make(map[string][]*ast.TypeDecl, len(ir[d]))
+			for name, decls := range ir[d] {
+				for _, decl := range decls {
+					types[name] = append(types[name], proto.Clone(decl).(*ast.TypeDecl))
+				}
+			}
+			scope[cdoc] = types
+		}
+
+		reduced, err := ReduceImports(scope)
+		if err != nil {
+			return nil, err
+		}
+
+		for fdoc, types := range reduced {
+			types, merrs := MergeExtensions(types)
+			errs = append(errs, merrs...)
+
+			flat[fdoc] = types
+		}
+	}
+
+	return flat, errs.ErrorOrNil()
+}
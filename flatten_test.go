@@ -0,0 +1,121 @@
+package compiler
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestFlatten(t *testing.T) {
+	docs, err := parser.ParseDocs(token.NewDocSet(), map[string]io.Reader{
+		"a": strings.NewReader(`@import(paths: ["b"])
+@gqlc(for: "doc")
+
+type Query {
+	user: User
+}
+
+extend type User {
+	name: String
+}`),
+		"b": strings.NewReader(`@import(paths: ["c"])
+
+type User {
+	id: ID!
+	joined: Time
+}
+
+type Unused {
+	id: ID!
+}`),
+		"c": strings.NewReader(`scalar Time`),
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := ToIR(docs)
+
+	flat, err := Flatten(ir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"a": `@gqlc(for: "doc")
+
+scalar Time
+
+type Query {
+	user: User
+}
+
+type User {
+	id: ID!
+	joined: Time
+	name: String
+}
+`,
+		"b": `scalar Time
+
+type Unused {
+	id: ID!
+}
+
+type User {
+	id: ID!
+	joined: Time
+}
+`,
+		"c": `scalar Time
+`,
+	}
+
+	out := FromIR(flat)
+	if len(out) != len(expected) {
+		t.Fatalf("expected %d documents but got: %d", len(expected), len(out))
+	}
+	for _, doc := range out {
+		var b bytes.Buffer
+		if err := WriteSDL(&b, doc); err != nil {
+			t.Fatal(err)
+		}
+
+		if b.String() != expected[doc.Name] {
+			t.Errorf("expected %s to be flattened to:\n%s\nbut got:\n%s", doc.Name, expected[doc.Name], b.String())
+		}
+	}
+
+	for doc := range ir {
+		if doc.Name == "a" && (len(DocImports(doc)) != 1 || len(ir[doc]["User"]) != 1) {
+			t.Error("expected IR to be left untouched")
+		}
+	}
+}
+
+func TestFlattenMergeErrors(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "a", strings.NewReader(`extend type A {
+	a: Int
+}
+
+extend type A {
+	b: Int
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	flat, err := Flatten(ToIR([]*ast.Document{doc}))
+	if err == nil {
+		t.Fatal("expected merge error")
+	}
+
+	docs := FromIR(flat)
+	if len(docs) != 1 || len(docs[0].Types) != 2 {
+		t.Errorf("expected the unmerged extensions to be kept but got: %v", docs)
+	}
+}
@@ -0,0 +1,47 @@
+//go:build go1.18
+// +build go1.18
+
+package compiler
+
+import (
+	"testing"
+
+	"github.com/gqlc/compiler/internal/fuzz"
+)
+
+var fuzzSeeds = [][]byte{
+	{},
+	{0, 4, 2, 0, 1, 2, 2, 1, 0, 1, 3, 0, 0},
+	{2, 0, 3, 2, 1, 4, 0, 2, 1, 1, 0, 0, 1, 0, 2, 1, 3, 0, 0, 0},
+	{1, 1, 6, 5, 2, 0, 3, 1, 2, 0, 0, 0, 4, 1, 2, 3, 0, 0, 5, 1},
+}
+
+// FuzzMergeExtensions checks that MergeExtensions doesn't panic on any
+// decoded Document.
+//
+func FuzzMergeExtensions(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, types := range ToIR(fuzz.Documents(data)) {
+			MergeExtensions(types)
+		}
+	})
+}
+
+// FuzzReduceImports checks that ReduceImports, and the import validator,
+// don't panic on any decoded set of Documents.
+//
+func FuzzReduceImports(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ir := ToIR(fuzz.Documents(data))
+		CheckTypes(ir, ImportValidator)
+		ReduceImports(ir)
+	})
+}
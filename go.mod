@@ -1,5 +1,8 @@
 module github.com/gqlc/compiler
 
-require github.com/gqlc/graphql v0.4.1
+require (
+	github.com/golang/protobuf v1.3.2
+	github.com/gqlc/graphql v0.4.1
+)
 
 go 1.13
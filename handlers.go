@@ -0,0 +1,85 @@
+package compiler
+
+import (
+	"context"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// DirectiveHandler processes an applied directive at compile time, the
+// way the compiler itself processes @import. It's given the Document the
+// directive is applied in and, for directives applied to a type, the type
+// declaration; decl is nil for directives applied to the Document.
+//
+// A DirectiveHandler may transform the IR, e.g. to remove the directive
+// or the type it's applied to, and returns any errors it encounters.
+//
+type DirectiveHandler func(ir IR, doc *ast.Document, decl *ast.TypeDecl, dir *ast.DirectiveLit) []error
+
+// RegisterDirectiveHandler registers the handler for the named directive
+// with the global registry.
+//
+func RegisterDirectiveHandler(name string, h DirectiveHandler) {
+	global.RegisterDirectiveHandler(name, h)
+}
+
+// ApplyDirectives calls the registered DirectiveHandler of every directive
+// applied to a Document or type in the IR.
+//
+func ApplyDirectives(ir IR) []error {
+	return ApplyDirectivesContext(context.Background(), ir)
+}
+
+// ApplyDirectivesContext is the same as ApplyDirectives, but uses the
+// handlers of any Registry carried by ctx, and stops once ctx is done.
+//
+// Directives are handled by Document name, with a Document's directives
+// before those of its types, which are in declaration order. The
+// applications are found before any handler is called, so handlers
+// can freely modify the IR.
+//
+func ApplyDirectivesContext(ctx context.Context, ir IR) (errs []error) {
+	r := RegistryFrom(ctx)
+
+	type application struct {
+		doc  *ast.Document
+		decl *ast.TypeDecl
+		dir  *ast.DirectiveLit
+		h    DirectiveHandler
+	}
+
+	var apps []application
+	add := func(doc *ast.Document, decl *ast.TypeDecl, dirs []*ast.DirectiveLit) {
+		for _, dir := range dirs {
+			if h := r.DirectiveHandler(dir.Name); h != nil {
+				apps = append(apps, application{doc: doc, decl: decl, dir: dir, h: h})
+			}
+		}
+	}
+
+	for _, doc := range ir.Documents() {
+		if IsBuiltins(doc) {
+			continue
+		}
+
+		add(doc, nil, doc.Directives)
+
+		types := ir[doc]
+		for _, name := range TypeNames(types) {
+			for _, decl := range types[name] {
+				if ts := TypeSpecOf(decl); ts != nil {
+					add(doc, decl, ts.Directives)
+				}
+			}
+		}
+	}
+
+	for _, app := range apps {
+		if err := ctx.Err(); err != nil {
+			return append(errs, err)
+		}
+
+		errs = append(errs, app.h(ir, app.doc, app.decl, app.dir)...)
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package compiler
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestApplyDirectives(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`@tag(name: "doc")
+
+type B @tag(name: "b") {
+	b: String
+}
+
+type A @drop {
+	a: String
+}
+
+scalar C @tag(name: "c") @unknown`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	ir := ToIR([]*ast.Document{doc})
+
+	var tags []string
+	r := NewRegistry(nil)
+	r.RegisterDirectiveHandler("tag", func(ir IR, doc *ast.Document, decl *ast.TypeDecl, dir *ast.DirectiveLit) []error {
+		tags = append(tags, ValueString(dir.Args.Args[0].Value.(*ast.Arg_BasicLit).BasicLit))
+		return nil
+	})
+	r.RegisterDirectiveHandler("drop", func(ir IR, doc *ast.Document, decl *ast.TypeDecl, dir *ast.DirectiveLit) []error {
+		name := TypeSpecOf(decl).Name.Name
+		delete(ir[doc], name)
+		return []error{errors.New("dropped: " + name)}
+	})
+
+	errs := ApplyDirectivesContext(WithRegistry(context.Background(), r), ir)
+	if len(errs) != 1 || errs[0].Error() != "dropped: A" {
+		t.Errorf("expected handler error but got: %v", errs)
+	}
+
+	expected := []string{`"doc"`, `"b"`, `"c"`}
+	if !reflect.DeepEqual(tags, expected) {
+		t.Errorf("expected tags: %v but got: %v", expected, tags)
+	}
+
+	if _, ok := ir[doc]["A"]; ok {
+		t.Error("expected handler to remove type: A")
+	}
+}
@@ -0,0 +1,185 @@
+package compiler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// SchemaHash returns a stable content hash of the schema described by the
+// IR, in the same form as Sum. The hash only depends on the types, fields,
+// arguments and applied directives of the schema, so it isn't affected by
+// descriptions, declaration order, how types are split across Documents, or
+// whether members are declared by extensions. Builtin types are excluded.
+//
+func SchemaHash(ir IR) string {
+	types := canonicalTypes(ir)
+
+	var b strings.Builder
+	for _, name := range sortedKeys(types) {
+		b.WriteString(name)
+		b.WriteByte('\n')
+		b.WriteString(types[name])
+	}
+
+	return Sum([]byte(b.String()))
+}
+
+// TypeHashes returns a stable content hash of each type in the IR, keyed by
+// type name, in the same form as SchemaHash. Comparing the hashes of two IRs
+// shows which types changed between them.
+//
+func TypeHashes(ir IR) map[string]string {
+	types := canonicalTypes(ir)
+
+	hashes := make(map[string]string, len(types))
+	for name, s := range types {
+		hashes[name] = Sum([]byte(s))
+	}
+	return hashes
+}
+
+// Equal reports whether two IRs describe the same schema, ignoring the same
+// details as SchemaHash, e.g. positions, descriptions and ordering. It's meant
+// for comparing schemas in tests, instead of comparing their printed SDL.
+//
+func Equal(a, b IR) bool {
+	ta, tb := canonicalTypes(a), canonicalTypes(b)
+	if len(ta) != len(tb) {
+		return false
+	}
+
+	for name, s := range ta {
+		if t, ok := tb[name]; !ok || s != t {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalTypes returns the canonical form of every non-builtin type in
+// the IR, keyed by type name. The form has one sorted line per member.
+//
+func canonicalTypes(ir IR) map[string]string {
+	types := make(map[string][]string)
+	for doc, mdecls := range ir {
+		if IsBuiltins(doc) {
+			continue
+		}
+
+		for name, decls := range mdecls {
+			for _, decl := range decls {
+				ts := TypeSpecOf(decl)
+				if ts == nil {
+					continue
+				}
+
+				l := types[name]
+				if !IsExtension(decl) {
+					l = append(l, "kind "+DeclTok(decl).String())
+				}
+				types[name] = append(l, canonicalSpec(ts)...)
+			}
+		}
+	}
+
+	canonical := make(map[string]string, len(types))
+	for name, lines := range types {
+		sort.Strings(lines)
+
+		var b strings.Builder
+		for _, l := range lines {
+			b.WriteString("\t")
+			b.WriteString(l)
+			b.WriteByte('\n')
+		}
+		canonical[name] = b.String()
+	}
+	return canonical
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// canonicalSpec returns a line for each member of a TypeSpec.
+func canonicalSpec(ts *ast.TypeSpec) (lines []string) {
+	for _, d := range ts.Directives {
+		lines = append(lines, "directive "+DirectiveString(d))
+	}
+
+	var fields *ast.FieldList
+	var args *ast.InputValueList
+	var members []*ast.Ident
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		fields = v.Schema.RootOps
+	case *ast.TypeSpec_Object:
+		fields = v.Object.Fields
+		members = v.Object.Interfaces
+	case *ast.TypeSpec_Interface:
+		fields = v.Interface.Fields
+	case *ast.TypeSpec_Union:
+		members = v.Union.Members
+	case *ast.TypeSpec_Enum:
+		fields = v.Enum.Values
+	case *ast.TypeSpec_Input:
+		args = v.Input.Fields
+	case *ast.TypeSpec_Directive:
+		args = v.Directive.Args
+		for _, l := range v.Directive.Locs {
+			lines = append(lines, "on "+l.Loc.String())
+		}
+	}
+
+	for _, m := range members {
+		lines = append(lines, "member "+m.Name)
+	}
+	if fields != nil {
+		for _, f := range fields.List {
+			s := "field " + f.Name.Name
+			if f.Args != nil && len(f.Args.List) > 0 {
+				var fargs []string
+				for _, a := range f.Args.List {
+					fargs = append(fargs, canonicalInput(a))
+				}
+				sort.Strings(fargs)
+				s += "(" + strings.Join(fargs, ", ") + ")"
+			}
+			if t := TypeString(fieldType(f)); t != "" {
+				s += ": " + t
+			}
+
+			lines = append(lines, s+canonicalDirectives(f.Directives))
+		}
+	}
+	if args != nil {
+		for _, a := range args.List {
+			lines = append(lines, "arg "+canonicalInput(a))
+		}
+	}
+	return
+}
+
+func canonicalInput(v *ast.InputValue) string {
+	s := v.Name.Name + ": " + TypeString(inputType(v))
+	if d := DefaultString(v); d != "" {
+		s += " = " + d
+	}
+	return s + canonicalDirectives(v.Directives)
+}
+
+func canonicalDirectives(dirs []*ast.DirectiveLit) string {
+	ds := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		ds = append(ds, " "+DirectiveString(d))
+	}
+	sort.Strings(ds)
+	return strings.Join(ds, "")
+}
@@ -0,0 +1,147 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestSchemaHash(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		A, B  []string
+		Equal bool
+	}{
+		{
+			Name: "Reordered",
+			A: []string{`type A {
+	a: String
+	b(x: Int = 1, y: [Int!]): B!
+}
+
+"Described."
+enum B {
+	ONE
+	TWO @deprecated
+}`},
+			B: []string{`enum B {
+	TWO @deprecated
+	ONE
+}
+
+type A {
+	b(y: [Int!], x: Int = 1): B!
+	a: String
+}`},
+			Equal: true,
+		},
+		{
+			Name: "SplitAcrossExtensionsAndDocuments",
+			A: []string{`type A implements I {
+	a: String
+	b: Int
+}
+
+interface I {
+	a: String
+}`},
+			B: []string{`type A {
+	a: String
+}`, `extend type A implements I {
+	b: Int
+}
+
+interface I {
+	a: String
+}`},
+			Equal: true,
+		},
+		{
+			Name: "ChangedType",
+			A: []string{`type A {
+	a: String
+}`},
+			B: []string{`type A {
+	a: String!
+}`},
+		},
+		{
+			Name: "ChangedDefault",
+			A: []string{`input A {
+	a: Int = 1
+}`},
+			B: []string{`input A {
+	a: Int = 2
+}`},
+		},
+		{
+			Name: "ChangedKind",
+			A: []string{`type A {
+	a: String
+}`},
+			B: []string{`interface A {
+	a: String
+}`},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			irA, irB := parseSources(subT, testCase.A), parseSources(subT, testCase.B)
+
+			a, b := SchemaHash(irA), SchemaHash(irB)
+			if (a == b) != testCase.Equal {
+				subT.Errorf("expected equal hashes to be %v, but got: %s and %s", testCase.Equal, a, b)
+			}
+
+			if Equal(irA, irB) != testCase.Equal {
+				subT.Errorf("expected Equal to be %v", testCase.Equal)
+			}
+		})
+	}
+}
+
+func TestTypeHashes(t *testing.T) {
+	a := TypeHashes(parseSources(t, []string{`type A {
+	a: String
+}
+
+type B {
+	b: Int
+}`}))
+	b := TypeHashes(parseSources(t, []string{`type B {
+	b: Int
+}
+
+type A {
+	a: String!
+}
+
+scalar C`}))
+
+	if len(a) != 2 || len(b) != 3 {
+		t.Fatalf("expected a hash per type but got: %v and %v", a, b)
+	}
+	if a["A"] == b["A"] {
+		t.Error("expected A to have changed")
+	}
+	if a["B"] != b["B"] {
+		t.Error("expected B to be unchanged")
+	}
+}
+
+func parseSources(t *testing.T, srcs []string) IR {
+	docs := make([]*ast.Document, 0, len(srcs))
+	for i, src := range srcs {
+		doc, err := parser.ParseDoc(token.NewDocSet(), string('a'+rune(i)), strings.NewReader(src), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		docs = append(docs, doc)
+	}
+	return ToIR(docs)
+}
@@ -0,0 +1,67 @@
+package compiler
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// comment syntax by file extension
+var (
+	lineComments = map[string]string{
+		".go": "//", ".js": "//", ".jsx": "//", ".ts": "//", ".tsx": "//",
+		".dart": "//", ".java": "//", ".kt": "//", ".swift": "//", ".scala": "//",
+		".c": "//", ".h": "//", ".cc": "//", ".cpp": "//", ".cs": "//",
+		".rs": "//", ".proto": "//",
+		".py": "#", ".rb": "#", ".sh": "#", ".yaml": "#", ".yml": "#",
+		".toml": "#", ".gql": "#", ".graphql": "#",
+		".sql": "--", ".lua": "--", ".hs": "--",
+	}
+
+	blockComments = map[string][2]string{
+		".md":   {"<!--", "-->"},
+		".html": {"<!--", "-->"},
+		".xml":  {"<!--", "-->"},
+		".css":  {"/*", "*/"},
+	}
+)
+
+// FileHeader returns the given lines, e.g. a generated-by notice, license
+// text or SchemaHash, as a comment in the syntax of the target language,
+// chosen by the file extension. Lines may contain newlines, e.g. a whole
+// license, and each of them is commented. Closing delimiters in the lines
+// of a block comment are broken up with a space, e.g. "-- >", so they
+// can't end the comment early. It returns an empty string for files whose
+// comment syntax is unknown, such as JSON.
+//
+func FileHeader(filename string, lines ...string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	lines = strings.Split(strings.Replace(strings.Join(lines, "\n"), "\r\n", "\n", -1), "\n")
+
+	ext := strings.ToLower(filepath.Ext(filename))
+	if prefix, ok := lineComments[ext]; ok {
+		var b strings.Builder
+		for _, l := range lines {
+			b.WriteString(strings.TrimRight(prefix+" "+l, " "))
+			b.WriteByte('\n')
+		}
+		b.WriteByte('\n')
+		return b.String()
+	}
+
+	if delims, ok := blockComments[ext]; ok {
+		end := delims[1]
+		escaped := end[:len(end)-1] + " " + end[len(end)-1:]
+
+		var b strings.Builder
+		b.WriteString(delims[0] + "\n")
+		for _, l := range lines {
+			b.WriteString(strings.Replace(l, end, escaped, -1))
+			b.WriteByte('\n')
+		}
+		b.WriteString(end + "\n\n")
+		return b.String()
+	}
+	return ""
+}
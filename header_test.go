@@ -0,0 +1,83 @@
+package compiler
+
+import "testing"
+
+func TestFileHeader(t *testing.T) {
+	lines := []string{"Code generated by gqlc. DO NOT EDIT.", "", "Licensed under MIT."}
+
+	testCases := []struct {
+		Name     string
+		File     string
+		Expected string
+	}{
+		{
+			Name:     "Go",
+			File:     "models/user.go",
+			Expected: "// Code generated by gqlc. DO NOT EDIT.\n//\n// Licensed under MIT.\n\n",
+		},
+		{
+			Name:     "Python",
+			File:     "user.PY",
+			Expected: "# Code generated by gqlc. DO NOT EDIT.\n#\n# Licensed under MIT.\n\n",
+		},
+		{
+			Name:     "Markdown",
+			File:     "docs/index.md",
+			Expected: "<!--\nCode generated by gqlc. DO NOT EDIT.\n\nLicensed under MIT.\n-->\n\n",
+		},
+		{
+			Name: "Unknown",
+			File: "schema.json",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			if h := FileHeader(testCase.File, lines...); h != testCase.Expected {
+				subT.Errorf("expected header: %q, but got: %q", testCase.Expected, h)
+			}
+		})
+	}
+}
+
+func TestFileHeaderMultiline(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		File     string
+		Lines    []string
+		Expected string
+	}{
+		{
+			Name:     "Go",
+			File:     "a.go",
+			Lines:    []string{"Copyright X\nLicensed MIT"},
+			Expected: "// Copyright X\n// Licensed MIT\n\n",
+		},
+		{
+			Name:     "CRLF",
+			File:     "a.py",
+			Lines:    []string{"Copyright X\r\n\r\nLicensed MIT", "Generated."},
+			Expected: "# Copyright X\n#\n# Licensed MIT\n# Generated.\n\n",
+		},
+		{
+			Name:     "Markdown",
+			File:     "a.md",
+			Lines:    []string{"a --> b\nc"},
+			Expected: "<!--\na -- > b\nc\n-->\n\n",
+		},
+		{
+			Name:     "CSS",
+			File:     "a.css",
+			Lines:    []string{"a */ b"},
+			Expected: "/*\na * / b\n*/\n\n",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			if h := FileHeader(testCase.File, testCase.Lines...); h != testCase.Expected {
+				subT.Errorf("expected header: %q, but got: %q", testCase.Expected, h)
+			}
+		})
+	}
+}
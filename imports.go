@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"container/list"
+	"context"
 	"fmt"
 	"strings"
 
@@ -12,10 +13,106 @@ import (
 type ImportError struct {
 	Doc *ast.Document
 	Msg string
+
+	// Underlying error, if any
+	Err error
 }
 
 func (e *ImportError) Error() string {
-	return fmt.Sprintf("compiler: import error encountered in %s:%s", e.Doc.Name, e.Msg)
+	return wrapMsg(fmt.Sprintf("compiler: import error encountered in %s:%s", e.Doc.Name, e.Msg), e.Err)
+}
+
+// Code returns the Diagnostic code of an ImportError: "import".
+func (e *ImportError) Code() string { return "import" }
+
+// Level returns the severity of an ImportError, which is always SeverityError.
+func (e *ImportError) Level() Severity { return SeverityError }
+
+// Position returns the Document with the import error.
+func (e *ImportError) Position() Position { return Position{Doc: e.Doc.Name} }
+
+// Unwrap returns the underlying error, if any.
+func (e *ImportError) Unwrap() error { return e.Err }
+
+// Import represents a single Document import declared with the @import directive.
+type Import struct {
+	// Path of the imported Document
+	Path string
+
+	// Version of the imported Document, if one was requested
+	Version string
+}
+
+// DocImports returns the imports declared by a Document.
+//
+// A Document may import others with either form of the @import directive:
+// @import(paths: ["a", "b"])
+// @import(path: "github.com/org/schemas/user", version: "v1.2.0")
+//
+func DocImports(doc *ast.Document) (imps []Import) {
+	for _, dir := range doc.Directives {
+		if dir.Name != "import" || dir.Args == nil {
+			continue
+		}
+
+		var paths []string
+		var version string
+		for _, arg := range dir.Args.Args {
+			name := "paths"
+			if arg.Name != nil {
+				name = arg.Name.Name
+			}
+
+			switch name {
+			case "path", "paths":
+				paths = append(paths, ArgStrings(arg)...)
+			case "version":
+				if v := ArgStrings(arg); len(v) > 0 {
+					version = v[0]
+				}
+			}
+		}
+
+		for _, path := range paths {
+			imps = append(imps, Import{Path: path, Version: version})
+		}
+	}
+	return
+}
+
+// ArgStrings returns the unquoted string value(s) of an argument, e.g.
+// the paths of @import(paths: ["a", "b"]).
+//
+func ArgStrings(arg *ast.Arg) (vals []string) {
+	var lits []*ast.BasicLit
+	switch v := arg.Value.(type) {
+	case *ast.Arg_BasicLit:
+		lits = append(lits, v.BasicLit)
+	case *ast.Arg_CompositeLit:
+		switch w := v.CompositeLit.Value.(type) {
+		case *ast.CompositeLit_BasicLit:
+			lits = append(lits, w.BasicLit)
+		case *ast.CompositeLit_ListLit:
+			switch x := w.ListLit.List.(type) {
+			case *ast.ListLit_BasicList:
+				lits = append(lits, x.BasicList.Values...)
+			case *ast.ListLit_CompositeList:
+				for _, c := range x.CompositeList.Values {
+					b, ok := c.Value.(*ast.CompositeLit_BasicLit)
+					if !ok {
+						continue
+					}
+
+					lits = append(lits, b.BasicLit)
+				}
+			}
+		}
+	}
+
+	for _, lit := range lits {
+		vals = append(vals, strings.Trim(lit.Value, "\""))
+	}
+	return
 }
 
 // node extends a ast.Document with its imports as children, thus
@@ -32,9 +129,18 @@ type node struct {
 // type defs into the Documents that they're imported into.
 //
 // To import a Document the @import directive is used:
-// directive @import(paths: [String]) on DOCUMENT
+// directive @import(paths: [String!], path: String, version: String) on DOCUMENT
 //
 func ReduceImports(docs IR) (IR, error) {
+	return ReduceImportsContext(context.Background(), docs)
+}
+
+// ReduceImportsContext is the same as ReduceImports, but stops once ctx is done.
+// The imports stage is reported to any Reporter carried by ctx.
+//
+func ReduceImportsContext(ctx context.Context, docs IR) (IR, error) {
+	defer Stage(ctx, "imports")()
+
 	// Map docs to nodes
 	dMap := make(map[string]*node, len(docs))
 	nodes := make([]*node, 0, len(docs))
@@ -52,6 +158,10 @@ func ReduceImports(docs IR) (IR, error) {
 
 	// Resolve import trees
 	for _, trie := range forest {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		err := resolveImports(trie)
 		if err != nil {
 			return nil, err
@@ -69,54 +179,38 @@ func ReduceImports(docs IR) (IR, error) {
 func createImportTries(nodes []*node, dMap map[string]*node) ([]*node, error) {
 	for i := 0; i < len(nodes); i++ {
 		n := nodes[i]
-		index := -1
-		for ind, dir := range n.Document.Directives {
-			if dir.Name != "import" {
-				continue
+		for _, imp := range DocImports(n.Document) {
+			id := dMap[imp.Path]
+			if id == nil {
+				return nil, &ImportError{
+					Doc: n.Document,
+					Msg: fmt.Sprintf("unknown import: %q", imp.Path),
+				}
 			}
-			index = ind
-
-			imps := dir.Args.Args[0]
-			compList := imps.Value.(*ast.Arg_CompositeLit).CompositeLit.Value.(*ast.CompositeLit_ListLit)
-
-			var paths []*ast.BasicLit
-			switch v := compList.ListLit.List.(type) {
-			case *ast.ListLit_BasicList:
-				paths = append(paths, v.BasicList.Values...)
-			case *ast.ListLit_CompositeList:
-				cpaths := v.CompositeList.Values
-				paths = make([]*ast.BasicLit, len(cpaths))
-				for i, c := range cpaths {
-					paths[i] = c.Value.(*ast.CompositeLit_BasicLit).BasicLit
+			if isCircular(n, id) {
+				return nil, &ImportError{
+					Doc: n.Document,
+					Msg: fmt.Sprintf("circular imports: %s <-> %s", n.Name, id.Name),
 				}
 			}
 
-			for _, imp := range paths {
-				path := strings.Trim(imp.Value, "\"")
-				id := dMap[path]
-				if id == nil {
-					return nil, &ImportError{
-						Doc: n.Document,
-						Msg: fmt.Sprintf("unknown import: %s", imp.Value),
-					}
-				}
-				if isCircular(n, id) {
-					return nil, &ImportError{
-						Doc: n.Document,
-						Msg: fmt.Sprintf("circular imports: %s <-> %s", n.Name, id.Name),
-					}
-				}
+			id.Imported = true
+			n.Childs = append(n.Childs, id)
+		}
 
-				id.Imported = true
-				n.Childs = append(n.Childs, id)
+		// Remove any @import directives
+		dirs := n.Document.Directives[:0]
+		for _, dir := range n.Document.Directives {
+			if dir.Name == "import" {
+				continue
 			}
-		}
 
-		if index > -1 {
-			copy(n.Document.Directives[index:], n.Document.Directives[index+1:])
-			n.Document.Directives[len(n.Document.Directives)-1] = nil // or the zero value of T
-			n.Document.Directives = n.Document.Directives[:len(n.Document.Directives)-1]
+			dirs = append(dirs, dir)
+		}
+		for j := len(dirs); j < len(n.Document.Directives); j++ {
+			n.Document.Directives[j] = nil // or the zero value of T
 		}
+		n.Document.Directives = dirs
 	}
 
 	// Remove any imported nodes which are not root nodes
@@ -137,6 +231,7 @@ func createImportTries(nodes []*node, dMap map[string]*node) ([]*node, error) {
 func resolveImports(root *node) error {
 	typeMap := make(map[string][]*ast.TypeDecl)
 	directives := make(map[string]*ast.DirectiveLit)
+	var order []string
 	defer func() {
 		removeBuiltins(typeMap)
 
@@ -145,13 +240,16 @@ func resolveImports(root *node) error {
 		if root.Directives != nil {
 			root.Directives = root.Directives[:0]
 		}
-		for _, d := range directives {
-			root.Directives = append(root.Directives, d)
+		for _, name := range order {
+			root.Directives = append(root.Directives, directives[name])
 		}
 	}()
 
 	// Collect root directives
 	for _, d := range root.Directives {
+		if _, exists := directives[d.Name]; !exists {
+			order = append(order, d.Name)
+		}
 		directives[d.Name] = d
 	}
 
@@ -176,6 +274,7 @@ func resolveImports(root *node) error {
 		for _, d := range n.Directives {
 			if _, exists := directives[d.Name]; !exists {
 				directives[d.Name] = d
+				order = append(order, d.Name)
 			}
 		}
 
@@ -200,9 +299,13 @@ func walk(q *list.List, typeMap map[string][]*ast.TypeDecl, f func(*node, map[st
 	return
 }
 
+// addTypes adds the types of n which are needed, but not yet defined, by
+// typeMap. Definitions are kept ahead of any extensions already added, so
+// types which are only extended by an importing Document can be merged.
+//
 func addTypes(n *node, typeMap map[string][]*ast.TypeDecl) {
 	for name, decls := range typeMap {
-		if len(decls) > 0 {
+		if isDefined(decls) {
 			continue
 		}
 
@@ -215,7 +318,7 @@ func addTypes(n *node, typeMap map[string][]*ast.TypeDecl) {
 			addDeps(decl, typeMap, n.Types)
 		}
 
-		typeMap[name] = append(decls, d...)
+		typeMap[name] = append(append([]*ast.TypeDecl{}, d...), decls...)
 	}
 
 	return
@@ -323,13 +426,30 @@ func resolveArgList(fields *ast.InputValueList, typeMap, peers map[string][]*ast
 	return
 }
 
+// isCircular reports whether a is b, or is imported by b, directly or
+// indirectly, i.e. whether a importing b would create a cycle.
+//
 func isCircular(a, b *node) bool {
-	for _, c := range b.Childs {
-		if a == c {
+	visited := make(map[*node]bool)
+
+	var reaches func(n *node) bool
+	reaches = func(n *node) bool {
+		if n == a {
 			return true
 		}
+		if visited[n] {
+			return false
+		}
+		visited[n] = true
+
+		for _, c := range n.Childs {
+			if reaches(c) {
+				return true
+			}
+		}
+		return false
 	}
-	return false
+	return reaches(b)
 }
 
 func isBuiltin(name string) bool {
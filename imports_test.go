@@ -315,6 +315,46 @@ func TestImportCycle(t *testing.T) {
 
 }
 
+func TestIndirectImportCycle(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Docs map[string]string
+	}{
+		{
+			Name: "Self",
+			Docs: map[string]string{"a": `@import(paths: ["a"])`},
+		},
+		{
+			Name: "Indirect",
+			Docs: map[string]string{
+				"a": `@import(paths: ["b"])`,
+				"b": `@import(paths: ["c"])`,
+				"c": `@import(paths: ["a"])`,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			readers := make(map[string]io.Reader, len(testCase.Docs))
+			for name, src := range testCase.Docs {
+				readers[name] = strings.NewReader(src)
+			}
+
+			docs, err := parser.ParseDocs(token.NewDocSet(), readers, 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			_, err = ReduceImports(ToIR(docs))
+			if err == nil || !strings.Contains(err.Error(), "circular imports") {
+				subT.Errorf("expected circular imports error but got: %v", err)
+			}
+		})
+	}
+}
+
 func TestResolveImports(t *testing.T) {
 	docs, err := parser.ParseDocs(token.NewDocSet(), map[string]io.Reader{"graph": strings.NewReader(graphGQL), "api": strings.NewReader(apiGQL)}, 0)
 	if err != nil {
@@ -392,6 +432,11 @@ type Service implements Doc {
 }`
 	twoGql = `@import(paths: ["thr"])
 
+interface Doc {
+	v: Version
+}`
+	versionedGql = `@import(path: "thr", version: "v1.2.0")
+
 interface Doc {
 	v: Version
 }`
@@ -435,6 +480,12 @@ func TestReduceImports(t *testing.T) {
 			TypesLen: map[string]int{"two": 2},
 			Docs:     map[string]io.Reader{"two": strings.NewReader(twoGql), "thr": strings.NewReader(thrGql)},
 		},
+		{
+			Name:     "VersionedImport",
+			DocsLen:  1,
+			TypesLen: map[string]int{"two": 2},
+			Docs:     map[string]io.Reader{"two": strings.NewReader(versionedGql), "thr": strings.NewReader(thrGql)},
+		},
 		{
 			Name:     "DiamondImport",
 			DocsLen:  1,
@@ -0,0 +1,57 @@
+package compiler
+
+import "github.com/gqlc/graphql/ast"
+
+// Symbol is a named type along with the Document which declares it.
+type Symbol struct {
+	Doc   *ast.Document
+	Decls []*ast.TypeDecl
+}
+
+// IndexSnapshot maps type names to their declarations across all the
+// Documents of an IR, so they can be looked up in constant time.
+//
+// An IndexSnapshot is a snapshot of the IR it was built from. It isn't
+// invalidated or updated when the IR changes, so it keeps answering with
+// the declarations the IR held when it was built. It must be rebuilt after
+// any transform which adds, removes, renames or replaces types, e.g.
+// ReduceImports, MergeExtensions, Prune, RenameTypes, Public, Normalize or
+// spec.Federate. Use the Lookup function when the IR may have changed.
+//
+type IndexSnapshot map[string]Symbol
+
+// NewIndexSnapshot indexes the types declared by the IR. If a name is declared
+// by more than one Document, the first Document, by name, which defines
+// the type is indexed. Documents which only extend the type are only
+// indexed when no Document defines it.
+//
+func NewIndexSnapshot(ir IR) IndexSnapshot {
+	var size int
+	for _, types := range ir {
+		size += len(types)
+	}
+
+	idx := make(IndexSnapshot, size)
+	for _, doc := range ir.Documents() {
+		for name, decls := range ir[doc] {
+			sym, ok := idx[name]
+			if ok && (isDefined(sym.Decls) || !isDefined(decls)) {
+				continue
+			}
+
+			idx[name] = Symbol{Doc: doc, Decls: decls}
+		}
+	}
+	return idx
+}
+
+// Lookup returns the Type and the Document it belongs to, as of when
+// the snapshot was built, or nil.
+//
+func (idx IndexSnapshot) Lookup(name string) (*ast.Document, []*ast.TypeDecl) {
+	sym, ok := idx[name]
+	if !ok {
+		return nil, nil
+	}
+	return sym.Doc, sym.Decls
+}
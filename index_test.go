@@ -0,0 +1,66 @@
+package compiler
+
+import (
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+func TestIndexSnapshot(t *testing.T) {
+	a := &ast.Document{Name: "a"}
+	b := &ast.Document{Name: "b"}
+
+	aDecls := []*ast.TypeDecl{{}}
+	bDecls := []*ast.TypeDecl{{}}
+	ext := []*ast.TypeDecl{{Spec: &ast.TypeDecl_TypeExtSpec{TypeExtSpec: &ast.TypeExtensionSpec{}}}}
+	def := []*ast.TypeDecl{{Spec: &ast.TypeDecl_TypeSpec{TypeSpec: &ast.TypeSpec{}}}}
+	ir := IR{
+		a: {"A": aDecls, "Shared": aDecls, "Extended": ext, "OnlyExtended": ext},
+		b: {"B": bDecls, "Shared": bDecls, "Extended": def},
+	}
+
+	idx := NewIndexSnapshot(ir)
+
+	testCases := []struct {
+		Name  string
+		Type  string
+		Doc   *ast.Document
+		Decls []*ast.TypeDecl
+	}{
+		{Name: "A", Type: "A", Doc: a, Decls: aDecls},
+		{Name: "B", Type: "B", Doc: b, Decls: bDecls},
+		{Name: "FirstDocument", Type: "Shared", Doc: a, Decls: aDecls},
+		{Name: "DefiningDocument", Type: "Extended", Doc: b, Decls: def},
+		{Name: "OnlyExtended", Type: "OnlyExtended", Doc: a, Decls: ext},
+		{Name: "Missing", Type: "C"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, decls := idx.Lookup(testCase.Type)
+			if doc != testCase.Doc {
+				subT.Errorf("expected document: %v but got: %v", testCase.Doc, doc)
+			}
+			if len(decls) != len(testCase.Decls) || (len(decls) > 0 && decls[0] != testCase.Decls[0]) {
+				subT.Errorf("expected declarations: %v but got: %v", testCase.Decls, decls)
+			}
+		})
+	}
+}
+
+func TestIndexSnapshotMutation(t *testing.T) {
+	doc := &ast.Document{Name: "a"}
+	decls := []*ast.TypeDecl{{}}
+	ir := IR{doc: {"A": decls}}
+
+	idx := NewIndexSnapshot(ir)
+	delete(ir[doc], "A")
+	ir[doc]["B"] = decls
+
+	if d, _ := idx.Lookup("A"); d != doc {
+		t.Errorf("expected snapshot to keep removed type but got: %v", d)
+	}
+	if d, _ := idx.Lookup("B"); d != nil {
+		t.Errorf("expected snapshot to not see added type but got: %v", d)
+	}
+}
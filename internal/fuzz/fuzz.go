@@ -0,0 +1,229 @@
+// Package fuzz decodes arbitrary bytes into GraphQL Documents, for the
+// fuzz targets of the compiler and its validators.
+package fuzz
+
+import (
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// Documents decodes arbitrary bytes into a set of Documents, for fuzzing
+// TypeCheckers and IR transforms. The Documents are structurally valid ASTs,
+// but may contain shapes the parser never produces, e.g. nil field lists,
+// extensions without a definition, or extensions of a different kind.
+//
+func Documents(data []byte) []*ast.Document {
+	d := &decoder{data: data}
+
+	docs := make([]*ast.Document, 1+d.next()%3)
+	for i := range docs {
+		doc := &ast.Document{Name: docNames[i]}
+		if d.next()%4 == 0 {
+			doc.Directives = append(doc.Directives, &ast.DirectiveLit{
+				Name: "import",
+				Args: &ast.CallExpr{Args: []*ast.Arg{
+					{
+						Name: &ast.Ident{Name: "paths"},
+						Value: &ast.Arg_CompositeLit{CompositeLit: &ast.CompositeLit{
+							Value: &ast.CompositeLit_ListLit{ListLit: &ast.ListLit{
+								List: &ast.ListLit_BasicList{BasicList: &ast.ListLit_Basic{
+									Values: []*ast.BasicLit{{Kind: token.Token_STRING, Value: `"` + d.pick(docNames) + `"`}},
+								}},
+							}},
+						}},
+					},
+				}},
+			})
+		}
+
+		for n := d.next() % 8; n > 0; n-- {
+			doc.Types = append(doc.Types, d.decl())
+		}
+		docs[i] = doc
+	}
+	return docs
+}
+
+var (
+	docNames  = []string{"a", "b", "c"}
+	typeNames = []string{"Query", "A", "B", "C", "Int", "String"}
+	names     = []string{"a", "b", "c", "__d"}
+	dirNames  = []string{"a", "b", "deprecated", "import"}
+	toks      = []token.Token{
+		token.Token_SCHEMA,
+		token.Token_SCALAR,
+		token.Token_TYPE,
+		token.Token_INTERFACE,
+		token.Token_UNION,
+		token.Token_ENUM,
+		token.Token_INPUT,
+		token.Token_DIRECTIVE,
+	}
+)
+
+type decoder struct {
+	data []byte
+	pos  int64
+}
+
+func (d *decoder) next() int {
+	if len(d.data) == 0 {
+		return 0
+	}
+
+	b := d.data[0]
+	d.data = d.data[1:]
+	d.pos++
+	return int(b)
+}
+
+func (d *decoder) pick(l []string) string { return l[d.next()%len(l)] }
+
+func (d *decoder) ident(l []string) *ast.Ident { return &ast.Ident{Name: d.pick(l)} }
+
+func (d *decoder) decl() *ast.TypeDecl {
+	tok := toks[d.next()%len(toks)]
+
+	ts := &ast.TypeSpec{Directives: d.directives()}
+	if tok != token.Token_SCHEMA {
+		ts.Name = d.ident(typeNames)
+		if tok == token.Token_DIRECTIVE {
+			ts.Name = d.ident(dirNames)
+		}
+	}
+
+	switch tok {
+	case token.Token_SCHEMA:
+		ts.Type = &ast.TypeSpec_Schema{Schema: &ast.SchemaType{RootOps: d.fields()}}
+	case token.Token_SCALAR:
+		ts.Type = &ast.TypeSpec_Scalar{Scalar: &ast.ScalarType{Name: ts.Name}}
+	case token.Token_TYPE:
+		obj := &ast.ObjectType{Fields: d.fields()}
+		for n := d.next() % 3; n > 0; n-- {
+			obj.Interfaces = append(obj.Interfaces, d.ident(typeNames))
+		}
+		ts.Type = &ast.TypeSpec_Object{Object: obj}
+	case token.Token_INTERFACE:
+		ts.Type = &ast.TypeSpec_Interface{Interface: &ast.InterfaceType{Fields: d.fields()}}
+	case token.Token_UNION:
+		union := &ast.UnionType{}
+		for n := d.next() % 3; n > 0; n-- {
+			union.Members = append(union.Members, d.ident(typeNames))
+		}
+		ts.Type = &ast.TypeSpec_Union{Union: union}
+	case token.Token_ENUM:
+		ts.Type = &ast.TypeSpec_Enum{Enum: &ast.EnumType{Values: d.fields()}}
+	case token.Token_INPUT:
+		ts.Type = &ast.TypeSpec_Input{Input: &ast.InputType{Fields: d.args()}}
+	case token.Token_DIRECTIVE:
+		dir := &ast.DirectiveType{Args: d.args()}
+		for n := d.next() % 3; n > 0; n-- {
+			dir.Locs = append(dir.Locs, &ast.DirectiveLocation{Loc: ast.DirectiveLocation_Loc(d.next() % len(ast.DirectiveLocation_Loc_name))})
+		}
+		ts.Type = &ast.TypeSpec_Directive{Directive: dir}
+	}
+
+	decl := &ast.TypeDecl{TokPos: d.pos, Tok: tok}
+	if d.next()%3 != 0 {
+		decl.Spec = &ast.TypeDecl_TypeSpec{TypeSpec: ts}
+		return decl
+	}
+
+	decl.Tok = token.Token_EXTEND
+	decl.Spec = &ast.TypeDecl_TypeExtSpec{TypeExtSpec: &ast.TypeExtensionSpec{Tok: tok, Type: ts}}
+	return decl
+}
+
+func (d *decoder) fields() *ast.FieldList {
+	n := d.next() % 5
+	if n == 0 {
+		return nil
+	}
+
+	l := &ast.FieldList{}
+	for ; n > 1; n-- {
+		f := &ast.Field{Name: d.ident(names), Directives: d.directives()}
+		switch t := d.typ(0).(type) {
+		case *ast.Ident:
+			f.Type = &ast.Field_Ident{Ident: t}
+		case *ast.List:
+			f.Type = &ast.Field_List{List: t}
+		case *ast.NonNull:
+			f.Type = &ast.Field_NonNull{NonNull: t}
+		}
+		f.Args = d.args()
+		l.List = append(l.List, f)
+	}
+	return l
+}
+
+func (d *decoder) args() *ast.InputValueList {
+	n := d.next() % 4
+	if n == 0 {
+		return nil
+	}
+
+	l := &ast.InputValueList{}
+	for ; n > 1; n-- {
+		v := &ast.InputValue{Name: d.ident(names), Directives: d.directives()}
+		switch t := d.typ(0).(type) {
+		case *ast.Ident:
+			v.Type = &ast.InputValue_Ident{Ident: t}
+		case *ast.List:
+			v.Type = &ast.InputValue_List{List: t}
+		case *ast.NonNull:
+			v.Type = &ast.InputValue_NonNull{NonNull: t}
+		}
+		if d.next()%4 == 0 {
+			v.Default = &ast.InputValue_BasicLit{BasicLit: &ast.BasicLit{Kind: token.Token_INT, Value: "1"}}
+		}
+		l.List = append(l.List, v)
+	}
+	return l
+}
+
+func (d *decoder) typ(depth int) interface{} {
+	if depth > 2 {
+		return d.ident(typeNames)
+	}
+
+	switch d.next() % 4 {
+	case 1:
+		l := &ast.List{}
+		switch t := d.typ(depth + 1).(type) {
+		case *ast.Ident:
+			l.Type = &ast.List_Ident{Ident: t}
+		case *ast.List:
+			l.Type = &ast.List_List{List: t}
+		case *ast.NonNull:
+			l.Type = &ast.List_NonNull{NonNull: t}
+		}
+		return l
+	case 2:
+		switch t := d.typ(depth + 1).(type) {
+		case *ast.Ident:
+			return &ast.NonNull{Type: &ast.NonNull_Ident{Ident: t}}
+		case *ast.List:
+			return &ast.NonNull{Type: &ast.NonNull_List{List: t}}
+		case *ast.NonNull:
+			return t
+		}
+	}
+	return d.ident(typeNames)
+}
+
+func (d *decoder) directives() (dirs []*ast.DirectiveLit) {
+	for n := d.next() % 3; n > 0; n-- {
+		dir := &ast.DirectiveLit{Name: d.pick(dirNames)}
+		if d.next()%2 == 0 {
+			dir.Args = &ast.CallExpr{Args: []*ast.Arg{
+				{
+					Name:  d.ident(names),
+					Value: &ast.Arg_BasicLit{BasicLit: &ast.BasicLit{Kind: token.Token_STRING, Value: `"x"`}},
+				},
+			}}
+		}
+		dirs = append(dirs, dir)
+	}
+	return
+}
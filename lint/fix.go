@@ -0,0 +1,108 @@
+package lint
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fixer is implemented by Rules which can suggest a fix for their issues.
+type Fixer interface {
+	// Fix returns a new name for the type or field an issue was reported
+	// on, e.g. fieldName for field_name, or an empty string if there isn't
+	// a suggestion.
+	Fix(name string) string
+}
+
+// Renames returns the fixes suggested by issues as a mapping of old names
+// to new names, which can be applied with compiler.RenameTypes or saved
+// with compiler.WriteRenames. Fields are mapped by their path, e.g.
+// User.first_name.
+//
+func Renames(issues []*Issue) map[string]string {
+	mapping := make(map[string]string)
+	for _, i := range issues {
+		if i.Fix == "" {
+			continue
+		}
+
+		path := i.Type
+		if i.Field != "" {
+			path += "." + i.Field
+		}
+		mapping[path] = i.Fix
+	}
+	return mapping
+}
+
+// words splits a name into its lower case words, e.g. firstName,
+// FirstName, first_name and FIRST_NAME all become: first, name.
+//
+func words(name string) (ws []string) {
+	runes := []rune(name)
+
+	var cur []rune
+	flush := func() {
+		if len(cur) > 0 {
+			ws = append(ws, strings.ToLower(string(cur)))
+			cur = cur[:0]
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				flush()
+			}
+		}
+		cur = append(cur, r)
+	}
+	flush()
+	return
+}
+
+func toCamelCase(name string) string {
+	ws := words(name)
+	for i := 1; i < len(ws); i++ {
+		ws[i] = title(ws[i])
+	}
+	return strings.Join(ws, "")
+}
+
+func toPascalCase(name string) string {
+	ws := words(name)
+	for i := range ws {
+		ws[i] = title(ws[i])
+	}
+	return strings.Join(ws, "")
+}
+
+func toScreamingSnake(name string) string {
+	return strings.ToUpper(strings.Join(words(name), "_"))
+}
+
+func title(w string) string {
+	if w == "" {
+		return w
+	}
+	return strings.ToUpper(w[:1]) + w[1:]
+}
+
+// singular returns the singular form of a plural English noun.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"),
+		strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "s"):
+		return name[:len(name)-1]
+	}
+	return name
+}
@@ -0,0 +1,214 @@
+// Package lint provides a pluggable framework for linting GraphQL documents.
+package lint
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+)
+
+// Severity represents how severe a lint issue is.
+type Severity uint8
+
+const (
+	// Off disables a rule.
+	Off Severity = iota
+
+	// Warning issues are reported, but shouldn't fail a build.
+	Warning
+
+	// Error issues are reported and should fail a build.
+	Error
+)
+
+var severities = [...]string{
+	Off:     "off",
+	Warning: "warning",
+	Error:   "error",
+}
+
+func (s Severity) String() string {
+	if int(s) < len(severities) {
+		return severities[s]
+	}
+	return fmt.Sprintf("Severity(%d)", s)
+}
+
+// Rule represents a single lint rule.
+type Rule interface {
+	// Code uniquely identifies the rule, e.g. GQLC2001
+	Code() string
+
+	// Name is a short human-readable name for the rule, e.g. type-description
+	Name() string
+
+	// Severity is the default severity of issues found by the rule
+	Severity() Severity
+
+	// Check checks a single type declaration, calling report for each
+	// issue found. The field is empty for issues with the type itself.
+	Check(decl *ast.TypeDecl, report func(field, msg string))
+}
+
+// Issue represents a problem found by a Rule.
+type Issue struct {
+	// Code of the Rule which found the issue
+	Code string
+
+	Severity Severity
+
+	// Document the issue was found in
+	Doc *ast.Document
+
+	// Type and field, if any, which the issue was found on
+	Type, Field string
+
+	// Issue message
+	Msg string
+
+	// Fix is the suggested new name of the type or field, if any, see Fixer
+	Fix string
+}
+
+// Error returns a string representation of an Issue.
+func (i *Issue) Error() string {
+	path := i.Type
+	if i.Field != "" {
+		path += "." + i.Field
+	}
+
+	return fmt.Sprintf("lint: %s in %s:%s: %s (%s)", i.Severity, i.Doc.Name, path, i.Msg, i.Code)
+}
+
+// Level returns the severity of the Issue for the compiler pipeline.
+func (i *Issue) Level() compiler.Severity {
+	if i.Severity == Error {
+		return compiler.SeverityError
+	}
+	return compiler.SeverityWarning
+}
+
+// Config overrides the severity of rules, keyed by either rule code or name.
+type Config map[string]Severity
+
+func (c Config) severity(r Rule) Severity {
+	if s, ok := c[r.Code()]; ok {
+		return s
+	}
+	if s, ok := c[r.Name()]; ok {
+		return s
+	}
+	return r.Severity()
+}
+
+// Lint runs the given rules against every type in the IR. If no rules
+// are provided then DefaultRules are used. Issues can be silenced
+// with the @suppress directive, see SuppressDirective.
+//
+// Rules which implement Fixer suggest fixes for their issues. Fixes to
+// the same type or field build on each other, in rule order, so the
+// last suggestion for a type or field includes all of them.
+//
+func Lint(ir compiler.IR, cfg Config, rules ...Rule) (issues []*Issue) {
+	if len(rules) == 0 {
+		rules = DefaultRules
+	}
+
+	for _, doc := range ir.Documents() {
+		if compiler.IsBuiltins(doc) {
+			continue
+		}
+
+		mdecls := ir[doc]
+		for _, name := range compiler.TypeNames(mdecls) {
+			for _, decl := range mdecls[name] {
+				sups := getSuppressions(decl)
+				fixes := make(map[string]string)
+
+				for _, r := range rules {
+					sev := cfg.severity(r)
+					if sev == Off {
+						continue
+					}
+
+					r.Check(decl, func(field, msg string) {
+						if sups.suppress(field, r) {
+							return
+						}
+
+						issue := &Issue{
+							Code:     r.Code(),
+							Severity: sev,
+							Doc:      doc,
+							Type:     name,
+							Field:    field,
+							Msg:      msg,
+						}
+						if f, ok := r.(Fixer); ok {
+							cur, ok := fixes[field]
+							if !ok {
+								cur = name
+								if field != "" {
+									cur = field
+								}
+							}
+
+							if fix := f.Fix(cur); fix != "" && fix != cur {
+								issue.Fix, fixes[field] = fix, fix
+							}
+						}
+						issues = append(issues, issue)
+					})
+				}
+
+				// Report any unused suppressions
+				sev := cfg.severity(UnusedSuppression)
+				if sev == Off {
+					continue
+				}
+
+				fields := make([]string, 0, len(sups))
+				for field := range sups {
+					fields = append(fields, field)
+				}
+				sort.Strings(fields)
+
+				for _, field := range fields {
+					ids := make([]string, 0, len(sups[field]))
+					for id, used := range sups[field] {
+						if !used {
+							ids = append(ids, id)
+						}
+					}
+					sort.Strings(ids)
+
+					for _, id := range ids {
+						issues = append(issues, &Issue{
+							Code:     UnusedSuppression.Code(),
+							Severity: sev,
+							Doc:      doc,
+							Type:     name,
+							Field:    field,
+							Msg:      fmt.Sprintf("unused suppression: %s", id),
+						})
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// Checker returns a TypeChecker which lints the IR, so that lint issues
+// are reported through CheckTypes along with any other type errors.
+//
+func Checker(cfg Config, rules ...Rule) compiler.TypeChecker {
+	return compiler.TypeCheckerFn(func(ir compiler.IR) (errs []error) {
+		for _, issue := range Lint(ir, cfg, rules...) {
+			errs = append(errs, issue)
+		}
+		return
+	})
+}
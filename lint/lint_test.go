@@ -0,0 +1,217 @@
+package lint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/compiler/spec"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestLint(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Src  string
+		Cfg  Config
+		Errs []string
+	}{
+		{
+			Name: "Clean",
+			Src: `"A user."
+type User {
+	id: ID!
+	fullName: String
+}
+
+"A role."
+enum Role {
+	ADMIN
+	READ_ONLY
+}`,
+		},
+		{
+			Name: "TypeDescription",
+			Src: `type User {
+	id: ID!
+}`,
+			Errs: []string{
+				"lint: warning in TypeDescription:User: type must have a description (GQLC2001)",
+			},
+		},
+		{
+			Name: "Naming",
+			Src: `"Users."
+type users {
+	Id: ID!
+	full_name: String
+}
+
+"Roles."
+enum Role {
+	admin
+	READ_ONLY
+}`,
+			Errs: []string{
+				"lint: warning in Naming:users.Id: field name must be camelCase (GQLC2002)",
+				"lint: warning in Naming:users.full_name: field name must be camelCase (GQLC2002)",
+				"lint: warning in Naming:users: type name must be PascalCase (GQLC2004)",
+				"lint: warning in Naming:users: type name should not be plural (GQLC2005)",
+				"lint: warning in Naming:Role.admin: enum value must be SCREAMING_SNAKE_CASE (GQLC2003)",
+			},
+		},
+		{
+			Name: "Config",
+			Src: `type Users {
+	Id: ID!
+}`,
+			Cfg: Config{
+				"GQLC2001":         Off,
+				"field-camel-case": Error,
+			},
+			Errs: []string{
+				"lint: error in Config:Users.Id: field name must be camelCase (GQLC2002)",
+				"lint: warning in Config:Users: type name should not be plural (GQLC2005)",
+			},
+		},
+		{
+			Name: "Suppress",
+			Src: `type Users @suppress(rules: ["GQLC2001", "no-plural-type-names"]) {
+	Id: ID! @suppress(rules: ["field-camel-case", "GQLC2003"])
+	Name: String
+}
+
+"Roles."
+enum Role @suppress(rules: ["GQLC2003"]) {
+	admin
+	readOnly
+}`,
+			Errs: []string{
+				"lint: warning in Suppress:Users.Name: field name must be camelCase (GQLC2002)",
+				"lint: warning in Suppress:Users.Id: unused suppression: GQLC2003 (GQLC2000)",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), testCase.Name, strings.NewReader(testCase.Src), 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			errs := compiler.CheckTypes(compiler.ToIR([]*ast.Document{doc}), Checker(testCase.Cfg))
+			if len(errs) != len(testCase.Errs) {
+				subT.Errorf("expected %d errors but got: %v", len(testCase.Errs), errs)
+				return
+			}
+
+			for i, err := range errs {
+				if err.Error() != testCase.Errs[i] {
+					subT.Errorf("expected error: %s, but got: %s", testCase.Errs[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestRenames(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`"Users."
+type users {
+	Id: ID!
+	full_name: String
+	HTTPProxy: String
+}
+
+"Categories."
+type Categories {
+	users: [users!]!
+}
+
+"Roles."
+enum Role {
+	readOnly
+	ADMIN
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := compiler.ToIR([]*ast.Document{doc})
+
+	renames := Renames(Lint(ir, nil))
+	expected := map[string]string{
+		"users":           "User",
+		"users.Id":        "id",
+		"users.full_name": "fullName",
+		"users.HTTPProxy": "httpProxy",
+		"Categories":      "Category",
+		"Role.readOnly":   "READ_ONLY",
+	}
+	if len(renames) != len(expected) {
+		t.Errorf("expected renames: %v but got: %v", expected, renames)
+	}
+	for old, n := range expected {
+		if renames[old] != n {
+			t.Errorf("expected %s to be renamed to: %s but got: %s", old, n, renames[old])
+		}
+	}
+
+	ir, err = compiler.RenameTypes(ir, renames)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issues := Lint(ir, nil); len(issues) != 0 {
+		t.Errorf("expected no issues after renaming but got: %v", issues)
+	}
+}
+
+func TestRegisterTypes(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`"Queries."
+type Query {
+	"The ID."
+	Id: ID @suppress(rules: ["field-camel-case"])
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := compiler.ToIR([]*ast.Document{doc})
+
+	r := compiler.NewRegistry(compiler.GlobalRegistry())
+	errs, err := compiler.CheckTypesContext(compiler.WithRegistry(context.Background(), r), ir, 0, spec.NewValidator(0), Checker(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Fatal("expected @suppress to be undefined without registering it")
+	}
+
+	RegisterTypes(r)
+	errs, err = compiler.CheckTypesContext(compiler.WithRegistry(context.Background(), r), ir, 0, spec.NewValidator(0), Checker(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	// Suppressions on arguments would never be used, since no rule reports them
+	doc, err = parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`"Queries."
+type Query {
+	"The user."
+	user(id: ID @suppress(rules: ["field-camel-case"])): ID
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs, err = compiler.CheckTypesContext(compiler.WithRegistry(context.Background(), r), compiler.ToIR([]*ast.Document{doc}), 0, spec.NewValidator(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) == 0 {
+		t.Error("expected @suppress to be invalid on arguments")
+	}
+}
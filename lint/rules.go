@@ -0,0 +1,194 @@
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+type rule struct {
+	code, name string
+	sev        Severity
+	check      func(*ast.TypeDecl, func(string, string))
+	fix        func(string) string
+}
+
+func (r *rule) Code() string       { return r.code }
+func (r *rule) Name() string       { return r.name }
+func (r *rule) Severity() Severity { return r.sev }
+
+func (r *rule) Check(decl *ast.TypeDecl, report func(field, msg string)) { r.check(decl, report) }
+
+func (r *rule) Fix(name string) string {
+	if r.fix == nil {
+		return ""
+	}
+	return r.fix(name)
+}
+
+// NewRule creates a Rule from a single check function.
+func NewRule(code, name string, sev Severity, check func(decl *ast.TypeDecl, report func(field, msg string))) Rule {
+	return &rule{code: code, name: name, sev: sev, check: check}
+}
+
+// NewFixRule creates a Rule from a single check function, along with a
+// fix function which suggests a new name for the type or field an issue
+// was reported on. See Fixer.
+//
+func NewFixRule(code, name string, sev Severity, check func(decl *ast.TypeDecl, report func(field, msg string)), fix func(name string) string) Rule {
+	return &rule{code: code, name: name, sev: sev, check: check, fix: fix}
+}
+
+// Default rules
+var (
+	// TypeDescription requires every type definition to have a description.
+	TypeDescription = NewRule("GQLC2001", "type-description", Warning, checkTypeDescription)
+
+	// FieldCamelCase requires field and input field names to be camelCase.
+	FieldCamelCase = NewFixRule("GQLC2002", "field-camel-case", Warning, checkFieldCamelCase, toCamelCase)
+
+	// EnumValueScreamingSnake requires enum values to be SCREAMING_SNAKE_CASE.
+	EnumValueScreamingSnake = NewFixRule("GQLC2003", "enum-value-screaming-snake", Warning, checkEnumValueScreamingSnake, toScreamingSnake)
+
+	// TypePascalCase requires type names to be PascalCase.
+	TypePascalCase = NewFixRule("GQLC2004", "type-pascal-case", Warning, checkTypePascalCase, toPascalCase)
+
+	// NoPluralTypeNames forbids plural type names.
+	NoPluralTypeNames = NewFixRule("GQLC2005", "no-plural-type-names", Warning, checkNoPluralTypeNames, singular)
+)
+
+// DefaultRules contains the rules used when none are given to Lint.
+var DefaultRules = []Rule{
+	TypeDescription,
+	FieldCamelCase,
+	EnumValueScreamingSnake,
+	TypePascalCase,
+	NoPluralTypeNames,
+}
+
+var (
+	camelCase      = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	pascalCase     = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+	screamingSnake = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// typeDef returns the TypeSpec of a named type definition, ignoring
+// extensions, the schema and directives.
+//
+func typeDef(decl *ast.TypeDecl) *ast.TypeSpec {
+	ts, ok := decl.Spec.(*ast.TypeDecl_TypeSpec)
+	if !ok {
+		return nil
+	}
+
+	switch decl.Tok {
+	case token.Token_SCHEMA, token.Token_DIRECTIVE:
+		return nil
+	}
+	return ts.TypeSpec
+}
+
+func checkTypeDescription(decl *ast.TypeDecl, report func(string, string)) {
+	if typeDef(decl) == nil || compiler.Description(decl.Doc) != "" {
+		return
+	}
+
+	report("", "type must have a description")
+}
+
+func checkFieldCamelCase(decl *ast.TypeDecl, report func(string, string)) {
+	var ts *ast.TypeSpec
+	switch v := decl.Spec.(type) {
+	case *ast.TypeDecl_TypeSpec:
+		ts = v.TypeSpec
+	case *ast.TypeDecl_TypeExtSpec:
+		ts = v.TypeExtSpec.Type
+	}
+
+	var names []string
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Object:
+		names = fieldNames(v.Object.Fields)
+	case *ast.TypeSpec_Interface:
+		names = fieldNames(v.Interface.Fields)
+	case *ast.TypeSpec_Input:
+		if v.Input.Fields == nil {
+			break
+		}
+
+		for _, f := range v.Input.Fields.List {
+			names = append(names, f.Name.Name)
+		}
+	}
+
+	for _, name := range names {
+		if !camelCase.MatchString(name) {
+			report(name, "field name must be camelCase")
+		}
+	}
+}
+
+func checkEnumValueScreamingSnake(decl *ast.TypeDecl, report func(string, string)) {
+	var ts *ast.TypeSpec
+	switch v := decl.Spec.(type) {
+	case *ast.TypeDecl_TypeSpec:
+		ts = v.TypeSpec
+	case *ast.TypeDecl_TypeExtSpec:
+		ts = v.TypeExtSpec.Type
+	}
+
+	enum, ok := ts.Type.(*ast.TypeSpec_Enum)
+	if !ok {
+		return
+	}
+
+	for _, name := range fieldNames(enum.Enum.Values) {
+		if !screamingSnake.MatchString(name) {
+			report(name, "enum value must be SCREAMING_SNAKE_CASE")
+		}
+	}
+}
+
+func checkTypePascalCase(decl *ast.TypeDecl, report func(string, string)) {
+	ts := typeDef(decl)
+	if ts == nil || pascalCase.MatchString(ts.Name.Name) {
+		return
+	}
+
+	report("", "type name must be PascalCase")
+}
+
+func checkNoPluralTypeNames(decl *ast.TypeDecl, report func(string, string)) {
+	ts := typeDef(decl)
+	if ts == nil {
+		return
+	}
+
+	name := ts.Name.Name
+	if !strings.HasSuffix(name, "s") {
+		return
+	}
+
+	// Common singular endings, e.g. Address, Status, Analysis
+	for _, suffix := range []string{"ss", "us", "is"} {
+		if strings.HasSuffix(name, suffix) {
+			return
+		}
+	}
+
+	report("", "type name should not be plural")
+}
+
+func fieldNames(fields *ast.FieldList) (names []string) {
+	if fields == nil {
+		return
+	}
+
+	for _, f := range fields.List {
+		names = append(names, f.Name.Name)
+	}
+	return
+}
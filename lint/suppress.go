@@ -0,0 +1,145 @@
+package lint
+
+import (
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// UnusedSuppression reports @suppress directives which didn't silence
+// any issues, so that suppressions don't outlive their purpose. Its
+// severity can be configured like any other rule.
+//
+var UnusedSuppression = NewRule("GQLC2000", "unused-suppression", Warning, func(*ast.TypeDecl, func(string, string)) {})
+
+// SuppressDirective silences issues, reported by the named rules, for
+// the type or field it's applied to. Rules can be named by either code
+// or name. Suppressions applied to a type also apply to its fields.
+//
+// directive @suppress(rules: [String!]!) on SCALAR | OBJECT | FIELD_DEFINITION | ...
+//
+// Only lint issues can be suppressed; other type errors, e.g. those
+// reported by spec.Validator, are unaffected. The directive isn't
+// registered globally, see RegisterTypes.
+//
+var SuppressDirective = &ast.TypeDecl{
+	Tok: token.Token_DIRECTIVE,
+	Spec: &ast.TypeDecl_TypeSpec{TypeSpec: &ast.TypeSpec{
+		Name: &ast.Ident{Name: "suppress"},
+		Type: &ast.TypeSpec_Directive{Directive: &ast.DirectiveType{
+			Locs: []*ast.DirectiveLocation{
+				{Loc: ast.DirectiveLocation_SCALAR},
+				{Loc: ast.DirectiveLocation_OBJECT},
+				{Loc: ast.DirectiveLocation_FIELD_DEFINITION},
+				{Loc: ast.DirectiveLocation_INTERFACE},
+				{Loc: ast.DirectiveLocation_UNION},
+				{Loc: ast.DirectiveLocation_ENUM},
+				{Loc: ast.DirectiveLocation_ENUM_VALUE},
+				{Loc: ast.DirectiveLocation_INPUT_OBJECT},
+				{Loc: ast.DirectiveLocation_INPUT_FIELD_DEFINITION},
+			},
+			Args: &ast.InputValueList{
+				List: []*ast.InputValue{
+					{
+						Name: &ast.Ident{Name: "rules"},
+						Type: &ast.InputValue_NonNull{
+							NonNull: &ast.NonNull{Type: &ast.NonNull_List{
+								List: &ast.List{Type: &ast.List_NonNull{
+									NonNull: &ast.NonNull{Type: &ast.NonNull_Ident{
+										Ident: &ast.Ident{Name: "String"},
+									}},
+								}},
+							}},
+						},
+					},
+				},
+			},
+		}},
+	}},
+}
+
+// RegisterTypes registers the @suppress directive with r, so documents
+// which apply it type check.
+//
+func RegisterTypes(r *compiler.Registry) {
+	r.RegisterTypes(SuppressDirective)
+}
+
+// suppressions maps a field name, or "" for the type itself, to the
+// rules suppressed on it and whether the suppression has been used.
+//
+type suppressions map[string]map[string]bool
+
+func (s suppressions) add(field string, dirs []*ast.DirectiveLit) {
+	for _, d := range dirs {
+		if d.Name != "suppress" || d.Args == nil {
+			continue
+		}
+
+		for _, arg := range d.Args.Args {
+			if arg.Name == nil || arg.Name.Name != "rules" {
+				continue
+			}
+
+			rules := s[field]
+			if rules == nil {
+				rules = make(map[string]bool)
+				s[field] = rules
+			}
+
+			for _, r := range compiler.ArgStrings(arg) {
+				rules[r] = false
+			}
+		}
+	}
+}
+
+// suppress reports whether an issue is suppressed, marking the
+// suppression as used.
+//
+func (s suppressions) suppress(field string, r Rule) bool {
+	return s.use(field, r) || field != "" && s.use("", r)
+}
+
+func (s suppressions) use(field string, r Rule) bool {
+	rules := s[field]
+	for _, id := range []string{r.Code(), r.Name()} {
+		if _, ok := rules[id]; ok {
+			rules[id] = true
+			return true
+		}
+	}
+	return false
+}
+
+func getSuppressions(decl *ast.TypeDecl) suppressions {
+	ts := compiler.TypeSpecOf(decl)
+
+	s := make(suppressions)
+	s.add("", ts.Directives)
+
+	var fields *ast.FieldList
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Object:
+		fields = v.Object.Fields
+	case *ast.TypeSpec_Interface:
+		fields = v.Interface.Fields
+	case *ast.TypeSpec_Enum:
+		fields = v.Enum.Values
+	case *ast.TypeSpec_Input:
+		if v.Input.Fields == nil {
+			break
+		}
+
+		for _, f := range v.Input.Fields.List {
+			s.add(f.Name.Name, f.Directives)
+		}
+	}
+
+	if fields != nil {
+		for _, f := range fields.List {
+			s.add(f.Name.Name, f.Directives)
+		}
+	}
+	return s
+}
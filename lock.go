@@ -0,0 +1,229 @@
+package compiler
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// LockFileName is the conventional name of a lock file.
+const LockFileName = "gqlc.lock"
+
+// Lock records the resolved version and content hash of an import.
+type Lock struct {
+	// Path of the imported Document
+	Path string
+
+	// Version the import was resolved to
+	Version string
+
+	// Sum is the hash of the imported Document's source
+	Sum string
+}
+
+// LockFile records the resolved versions of remote imports, so that
+// builds are reproducible across machines. It is keyed by import path.
+//
+// A lock file contains one lock per line in the form:
+// <path> <version> <sum>
+//
+// Fields which are empty, or contain spaces or quotes, are written as
+// quoted Go strings, e.g. "my schemas/user".
+//
+type LockFile map[string]*Lock
+
+// ReadLockFile reads a LockFile.
+func ReadLockFile(r io.Reader) (LockFile, error) {
+	f := make(LockFile)
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" {
+			continue
+		}
+
+		parts, err := lockFields(text)
+		if err != nil || len(parts) != 3 {
+			return nil, fmt.Errorf("compiler: malformed lock on line %d: %s", line, text)
+		}
+
+		f[parts[0]] = &Lock{Path: parts[0], Version: parts[1], Sum: parts[2]}
+	}
+
+	return f, s.Err()
+}
+
+// lockFields splits a line of a lock file into its, possibly quoted, fields.
+func lockFields(line string) ([]string, error) {
+	var fields []string
+	for {
+		line = strings.TrimLeftFunc(line, unicode.IsSpace)
+		if line == "" {
+			return fields, nil
+		}
+
+		if line[0] != '"' {
+			i := strings.IndexFunc(line, unicode.IsSpace)
+			if i < 0 {
+				i = len(line)
+			}
+
+			fields = append(fields, line[:i])
+			line = line[i:]
+			continue
+		}
+
+		end := 1
+		for ; end < len(line) && line[end] != '"'; end++ {
+			if line[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(line) {
+			return nil, strconv.ErrSyntax
+		}
+
+		field, err := strconv.Unquote(line[:end+1])
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+		line = line[end+1:]
+	}
+}
+
+// quoteLockField quotes a field of a lock file, if it's empty or contains
+// spaces, quotes or any other characters which need escaping.
+//
+func quoteLockField(field string) string {
+	q := strconv.Quote(field)
+	if field == "" || q != `"`+field+`"` || strings.IndexFunc(field, unicode.IsSpace) >= 0 {
+		return q
+	}
+	return field
+}
+
+// WriteTo writes the LockFile to w, sorted by import path.
+func (f LockFile) WriteTo(w io.Writer) (int64, error) {
+	paths := make([]string, 0, len(f))
+	for path := range f {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var n int64
+	for _, path := range paths {
+		l := f[path]
+
+		c, err := fmt.Fprintf(w, "%s %s %s\n", quoteLockField(l.Path), quoteLockField(l.Version), quoteLockField(l.Sum))
+		n += int64(c)
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return n, nil
+}
+
+// Add records the resolution of an import with the given source.
+func (f LockFile) Add(imp Import, src []byte) {
+	f[imp.Path] = &Lock{Path: imp.Path, Version: imp.Version, Sum: Sum(src)}
+}
+
+// Verify checks that an import and its resolved source match the
+// version and hash recorded in the LockFile. Imports which haven't
+// been recorded are always valid, and imports which don't request a
+// version accept the locked one, as with VerifyVersions.
+//
+func (f LockFile) Verify(imp Import, src []byte) error {
+	l, ok := f[imp.Path]
+	if !ok {
+		return nil
+	}
+
+	if imp.Version != "" && l.Version != imp.Version {
+		return fmt.Errorf("compiler: %s: version %s does not match locked version %s", imp.Path, imp.Version, l.Version)
+	}
+
+	if sum := Sum(src); l.Sum != sum {
+		return fmt.Errorf("compiler: %s@%s: checksum mismatch: %s != %s", imp.Path, imp.Version, sum, l.Sum)
+	}
+
+	return nil
+}
+
+// VerifyVersions checks that every versioned import in the IR requests
+// the version recorded in the LockFile, so a build fails before imports
+// are reduced, see ReduceImports, rather than silently resolving another
+// version. Imports which don't request a version accept the locked one.
+// Sources are checked as they're resolved, see Verify.
+//
+func (f LockFile) VerifyVersions(ir IR) error {
+	var errs MultiError
+	for _, doc := range ir.Documents() {
+		for _, imp := range DocImports(doc) {
+			l, ok := f[imp.Path]
+			if !ok || imp.Version == "" || l.Version == imp.Version {
+				continue
+			}
+
+			errs = append(errs, &ImportError{
+				Doc: doc,
+				Msg: fmt.Sprintf("version %s of import: %s does not match locked version %s", imp.Version, imp.Path, l.Version),
+			})
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
+// Sum returns the hash of a Document source.
+func Sum(src []byte) string {
+	h := sha256.Sum256(src)
+	return "sha256:" + hex.EncodeToString(h[:])
+}
+
+// VersionedImports returns every import in the IR which requests a
+// specific version, sorted by path. An import path may only be
+// requested at a single version.
+//
+func VersionedImports(ir IR) ([]Import, error) {
+	seen := make(map[string]*ast.Document)
+	versions := make(map[string]string)
+
+	var imps []Import
+	for _, doc := range ir.Documents() {
+		for _, imp := range DocImports(doc) {
+			if imp.Version == "" {
+				continue
+			}
+
+			v, ok := versions[imp.Path]
+			if !ok {
+				seen[imp.Path] = doc
+				versions[imp.Path] = imp.Version
+				imps = append(imps, imp)
+				continue
+			}
+
+			if v != imp.Version {
+				return nil, &ImportError{
+					Doc: doc,
+					Msg: fmt.Sprintf("conflicting versions for import: %s: %s (%s) and %s", imp.Path, v, seen[imp.Path].Name, imp.Version),
+				}
+			}
+		}
+	}
+
+	sort.Slice(imps, func(i, j int) bool { return imps[i].Path < imps[j].Path })
+	return imps, nil
+}
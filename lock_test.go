@@ -0,0 +1,234 @@
+package compiler
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestLockFile(t *testing.T) {
+	src := []byte("scalar Time")
+	imp := Import{Path: "github.com/org/schemas/time", Version: "v1.2.0"}
+
+	f := make(LockFile)
+	f.Add(imp, src)
+
+	var b bytes.Buffer
+	if _, err := f.WriteTo(&b); err != nil {
+		t.Error(err)
+		return
+	}
+
+	rf, err := ReadLockFile(&b)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	testCases := []struct {
+		Name string
+		Imp  Import
+		Src  []byte
+		Err  bool
+	}{
+		{
+			Name: "Match",
+			Imp:  imp,
+			Src:  src,
+		},
+		{
+			Name: "Unlocked",
+			Imp:  Import{Path: "github.com/org/schemas/user", Version: "v0.1.0"},
+			Src:  src,
+		},
+		{
+			Name: "Unversioned",
+			Imp:  Import{Path: imp.Path},
+			Src:  src,
+		},
+		{
+			Name: "UnversionedSumMismatch",
+			Imp:  Import{Path: imp.Path},
+			Src:  []byte("scalar Date"),
+			Err:  true,
+		},
+		{
+			Name: "VersionMismatch",
+			Imp:  Import{Path: imp.Path, Version: "v1.3.0"},
+			Src:  src,
+			Err:  true,
+		},
+		{
+			Name: "SumMismatch",
+			Imp:  imp,
+			Src:  []byte("scalar Date"),
+			Err:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			err := rf.Verify(testCase.Imp, testCase.Src)
+			if (err != nil) != testCase.Err {
+				subT.Logf("unexpected verification result: %v", err)
+				subT.Fail()
+			}
+		})
+	}
+}
+
+func TestReadLockFile_Malformed(t *testing.T) {
+	for _, src := range []string{
+		"github.com/org/schemas/time v1.2.0\n",
+		"\"github.com/org/my schemas v1.2.0 sha256:00\n",
+		"\"github.com/org/\\q\" v1.2.0 sha256:00\n",
+	} {
+		if _, err := ReadLockFile(strings.NewReader(src)); err == nil {
+			t.Errorf("expected error for malformed lock: %q", src)
+		}
+	}
+}
+
+func TestLockFileQuoting(t *testing.T) {
+	f := LockFile{
+		"my schemas/user":  {Path: "my schemas/user", Version: "v1.0.0", Sum: "sha256:00"},
+		"schemas/\"time\"": {Path: "schemas/\"time\"", Sum: "sha256:01"},
+		"schemas/date":     {Path: "schemas/date", Version: "v2.0.0", Sum: "sha256:02"},
+	}
+
+	var b bytes.Buffer
+	if _, err := f.WriteTo(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `"my schemas/user" v1.0.0 sha256:00
+"schemas/\"time\"" "" sha256:01
+schemas/date v2.0.0 sha256:02
+`
+	if b.String() != expected {
+		t.Errorf("expected lock file:\n%s\nbut got:\n%s", expected, b.String())
+	}
+
+	rf, err := ReadLockFile(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rf) != len(f) {
+		t.Fatalf("expected %d locks but got: %v", len(f), rf)
+	}
+	for path, l := range f {
+		if rl, ok := rf[path]; !ok || *rl != *l {
+			t.Errorf("expected lock: %v, but got: %v", l, rf[path])
+		}
+	}
+}
+
+func TestVerifyVersions(t *testing.T) {
+	docs, err := parser.ParseDocs(token.NewDocSet(), map[string]io.Reader{
+		"a": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.3.0")`),
+		"b": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.2.0")`),
+		"c": strings.NewReader(`@import(path: "github.com/org/schemas/time", version: "v0.1.0")`),
+		"d": strings.NewReader(`@import(path: "github.com/org/schemas/user")`),
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := make(LockFile)
+	f.Add(Import{Path: "github.com/org/schemas/user", Version: "v1.2.0"}, []byte("type User"))
+
+	err = f.VerifyVersions(ToIR(docs))
+	if err == nil {
+		t.Fatal("expected version mismatch")
+	}
+
+	expected := "compiler: import error encountered in a:version v1.3.0 of import: github.com/org/schemas/user does not match locked version v1.2.0"
+	if err.Error() != expected {
+		t.Errorf("expected error: %s, but got: %s", expected, err)
+	}
+}
+
+func TestVersionedImports(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Docs map[string]io.Reader
+		Imps []Import
+		Err  bool
+	}{
+		{
+			Name: "Unversioned",
+			Docs: map[string]io.Reader{
+				"a": strings.NewReader(`@import(paths: ["b"])`),
+				"b": strings.NewReader(`scalar Time`),
+			},
+		},
+		{
+			Name: "Versioned",
+			Docs: map[string]io.Reader{
+				"a": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.2.0")`),
+				"b": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.2.0")`),
+			},
+			Imps: []Import{{Path: "github.com/org/schemas/user", Version: "v1.2.0"}},
+		},
+		{
+			Name: "Conflicting",
+			Docs: map[string]io.Reader{
+				"a": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.2.0")`),
+				"b": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.3.0")`),
+			},
+			Err: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			docs, err := parser.ParseDocs(token.NewDocSet(), testCase.Docs, 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			imps, err := VersionedImports(ToIR(docs))
+			if (err != nil) != testCase.Err {
+				subT.Logf("unexpected error: %v", err)
+				subT.Fail()
+				return
+			}
+
+			if len(imps) != len(testCase.Imps) {
+				subT.Logf("expected %d imports but got: %d", len(testCase.Imps), len(imps))
+				subT.Fail()
+				return
+			}
+
+			for i, imp := range imps {
+				if imp != testCase.Imps[i] {
+					subT.Logf("expected import: %v, but got: %v", testCase.Imps[i], imp)
+					subT.Fail()
+				}
+			}
+		})
+	}
+}
+
+func TestVersionedImportsConflict(t *testing.T) {
+	docs, err := parser.ParseDocs(token.NewDocSet(), map[string]io.Reader{
+		"a": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.2.0")`),
+		"b": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.3.0")`),
+		"c": strings.NewReader(`@import(path: "github.com/org/schemas/user", version: "v1.4.0")`),
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "compiler: import error encountered in b:conflicting versions for import: github.com/org/schemas/user: v1.2.0 (a) and v1.3.0"
+	for i := 0; i < 10; i++ {
+		if _, err := VersionedImports(ToIR(docs)); err == nil || err.Error() != expected {
+			t.Fatalf("expected error: %s, but got: %v", expected, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package compiler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ManifestFileName is the conventional name of a generation manifest.
+const ManifestFileName = "gqlc.manifest.json"
+
+// ManifestEntry records a single generated file.
+type ManifestEntry struct {
+	// Path of the generated file
+	Path string `json:"path"`
+
+	// Name of the Document the file was generated from
+	Doc string `json:"doc"`
+
+	// Name of the generator which produced the file
+	Generator string `json:"generator"`
+
+	// Sum is the hash of the file's contents
+	Sum string `json:"sum"`
+}
+
+// Manifest lists every file produced by a build, keyed by path, enabling
+// clean-up of stale outputs and incremental regeneration checks.
+//
+// A manifest is stored as JSON, with its entries sorted by path:
+// {"files": [{"path": "...", "doc": "...", "generator": "...", "sum": "..."}]}
+//
+type Manifest map[string]*ManifestEntry
+
+type manifestJSON struct {
+	Files []*ManifestEntry `json:"files"`
+}
+
+// ReadManifest reads a Manifest.
+func ReadManifest(r io.Reader) (Manifest, error) {
+	var mj manifestJSON
+	if err := json.NewDecoder(r).Decode(&mj); err != nil {
+		return nil, fmt.Errorf("compiler: malformed manifest: %s", err)
+	}
+
+	m := make(Manifest, len(mj.Files))
+	for _, e := range mj.Files {
+		m[e.Path] = e
+	}
+	return m, nil
+}
+
+// WriteTo writes the Manifest to w, sorted by path.
+func (m Manifest) WriteTo(w io.Writer) (int64, error) {
+	mj := manifestJSON{Files: make([]*ManifestEntry, 0, len(m))}
+	for _, e := range m {
+		mj.Files = append(mj.Files, e)
+	}
+	sort.Slice(mj.Files, func(i, j int) bool { return mj.Files[i].Path < mj.Files[j].Path })
+
+	b, err := json.MarshalIndent(mj, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(append(b, '\n'))
+	return int64(n), err
+}
+
+// Add records a generated file with the given contents.
+func (m Manifest) Add(path, doc, generator string, contents []byte) {
+	m[path] = &ManifestEntry{Path: path, Doc: doc, Generator: generator, Sum: Sum(contents)}
+}
+
+// UpToDate reports whether the file at path was recorded with the given contents.
+func (m Manifest) UpToDate(path string, contents []byte) bool {
+	e, ok := m[path]
+	return ok && e.Sum == Sum(contents)
+}
+
+// Stale returns the paths, sorted, of the files in m which are no longer
+// produced by the newer build, and can be cleaned up.
+//
+func (m Manifest) Stale(newer Manifest) (paths []string) {
+	for path := range m {
+		if _, ok := newer[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return
+}
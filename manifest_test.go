@@ -0,0 +1,52 @@
+package compiler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestManifest(t *testing.T) {
+	old := make(Manifest)
+	old.Add("models/user.go", "user.gql", "go", []byte("package models"))
+	old.Add("docs/user.md", "user.gql", "doc", []byte("# User"))
+
+	var buf bytes.Buffer
+	if _, err := old.WriteTo(&buf); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if i, j := strings.Index(buf.String(), "docs/user.md"), strings.Index(buf.String(), "models/user.go"); i > j {
+		t.Error("expected manifest entries to be sorted by path")
+	}
+
+	m, err := ReadManifest(&buf)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if !m.UpToDate("models/user.go", []byte("package models")) {
+		t.Error("expected models/user.go to be up to date")
+	}
+	if m.UpToDate("models/user.go", []byte("package users")) {
+		t.Error("expected models/user.go to be out of date")
+	}
+	if m.UpToDate("models/role.go", nil) {
+		t.Error("expected unrecorded file to be out of date")
+	}
+	if e := m["docs/user.md"]; e.Doc != "user.gql" || e.Generator != "doc" {
+		t.Errorf("unexpected manifest entry: %v", e)
+	}
+
+	newer := make(Manifest)
+	newer.Add("models/user.go", "user.gql", "go", []byte("package models"))
+	if stale := m.Stale(newer); len(stale) != 1 || stale[0] != "docs/user.md" {
+		t.Errorf("expected docs/user.md to be stale but got: %v", stale)
+	}
+
+	if _, err = ReadManifest(strings.NewReader("{")); err == nil {
+		t.Error("expected error for malformed manifest")
+	}
+}
@@ -1,26 +1,94 @@
 package compiler
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gqlc/graphql/ast"
 	"github.com/gqlc/graphql/token"
 )
 
+// MergeError represents an extension which could not be merged
+// with its original type declaration.
+//
+type MergeError struct {
+	// Name of the type being merged
+	Type string
+
+	// Merge error message
+	Msg string
+
+	// Underlying error, if any
+	Err error
+
+	// Pos of the declaration which could not be merged, if known
+	Pos int64
+}
+
+// Error returns a string representation of a MergeError.
+func (e *MergeError) Error() string {
+	return wrapMsg(fmt.Sprintf("compiler: merge error encountered in %s: %s", e.Type, e.Msg), e.Err)
+}
+
+// Code returns the Diagnostic code of a MergeError: "merge".
+func (e *MergeError) Code() string { return "merge" }
+
+// Level returns the severity of a MergeError, which is always SeverityError.
+func (e *MergeError) Level() Severity { return SeverityError }
+
+// Position returns the type, and declaration, which could not be merged.
+func (e *MergeError) Position() Position { return Position{Type: e.Type, Pos: e.Pos} }
+
+// Unwrap returns the underlying error, if any.
+func (e *MergeError) Unwrap() error { return e.Err }
+
 // MergeExtensions merges type extensions with their original declaration.
-func MergeExtensions(types map[string][]*ast.TypeDecl) map[string][]*ast.TypeDecl {
-	for name, decls := range types {
+//
+// Any extensions which can't be merged, as well as extension members which
+// duplicate or conflict with already declared members, are reported as
+// MergeErrors and are left out of the merged declaration. Types which
+// can't be merged at all are left as is.
+//
+// Any descriptions and comments attached to extensions are appended to
+// those of the original declaration, so they aren't lost.
+//
+func MergeExtensions(types map[string][]*ast.TypeDecl) (map[string][]*ast.TypeDecl, []error) {
+	return MergeExtensionsContext(context.Background(), types)
+}
+
+// MergeExtensionsContext is the same as MergeExtensions, but uses the
+// repeatable directives of any Registry carried by ctx, so extensions may
+// apply them again.
+//
+func MergeExtensionsContext(ctx context.Context, types map[string][]*ast.TypeDecl) (map[string][]*ast.TypeDecl, []error) {
+	r := RegistryFrom(ctx)
+
+	var errs []error
+	for _, name := range TypeNames(types) {
+		decls := types[name]
 		if len(decls) == 1 {
 			continue
 		}
 
-		types[name] = mergeDecls(decls)
+		types[name] = mergeDecls(r, name, decls, &errs)
 	}
-	return types
+	return types, errs
 }
 
-func mergeDecls(decls []*ast.TypeDecl) []*ast.TypeDecl {
-	f := func(_, _ *ast.TypeSpec) {}
+type merger func(def, ext *ast.TypeSpec, report func(format string, args ...interface{}))
+
+func mergeDecls(r *Registry, name string, decls []*ast.TypeDecl, errs *[]error) []*ast.TypeDecl {
+	report := func(format string, args ...interface{}) {
+		*errs = append(*errs, &MergeError{Type: name, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	def, ok := decls[0].Spec.(*ast.TypeDecl_TypeSpec)
+	if !ok {
+		report("missing type declaration")
+		return decls
+	}
+
+	f := func(_, _ *ast.TypeSpec, _ func(string, ...interface{})) {}
 	switch decls[0].Tok {
 	case token.Token_SCHEMA:
 		f = mergeSchema
@@ -36,78 +104,203 @@ func mergeDecls(decls []*ast.TypeDecl) []*ast.TypeDecl {
 	case token.Token_INPUT:
 		f = mergeInput
 	default:
-		panic(fmt.Sprintf("type of: %s cannot be extended", decls[0].Tok))
-	}
-
-	def, ok := decls[0].Spec.(*ast.TypeDecl_TypeSpec)
-	if !ok {
-		panic("") // TODO: Should be panic?
+		report("type of: %s cannot be extended", decls[0].Tok)
+		return decls
 	}
 
 	for _, edecl := range decls[1:] {
 		ext, ok := edecl.Spec.(*ast.TypeDecl_TypeExtSpec)
 		if !ok {
-			panic("") // TODO: Should be panic?
+			*errs = append(*errs, &MergeError{Type: name, Msg: "cannot have more than one type definition", Pos: edecl.TokPos})
+			continue
 		}
 
-		f(def.TypeSpec, ext.TypeExtSpec.Type)
+		if ext.TypeExtSpec.Tok != decls[0].Tok {
+			report("cannot extend %s with %s extension", decls[0].Tok, ext.TypeExtSpec.Tok)
+			continue
+		}
+
+		f(def.TypeSpec, ext.TypeExtSpec.Type, report)
+		mergeDoc(decls[0], edecl)
+
+		for _, d := range ext.TypeExtSpec.Type.Directives {
+			if !r.IsRepeatable(d.Name) && hasDirective(def.TypeSpec.Directives, d.Name) {
+				report("directive already applied: @%s", d.Name)
+				continue
+			}
 
-		def.TypeSpec.Directives = append(def.TypeSpec.Directives, ext.TypeExtSpec.Type.Directives...)
+			def.TypeSpec.Directives = append(def.TypeSpec.Directives, d)
+		}
 	}
 
 	return decls[:1]
 }
 
-func mergeSchema(def, ext *ast.TypeSpec) {
+// mergeDoc appends the documentation of an extension to the declaration.
+func mergeDoc(decl, ext *ast.TypeDecl) {
+	if ext.Doc == nil || len(ext.Doc.List) == 0 {
+		return
+	}
+
+	if decl.Doc == nil {
+		decl.Doc = &ast.DocGroup{}
+	}
+	decl.Doc.List = append(decl.Doc.List, ext.Doc.List...)
+}
+
+func hasDirective(dirs []*ast.DirectiveLit, name string) bool {
+	for _, d := range dirs {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeSchema(def, ext *ast.TypeSpec, report func(string, ...interface{})) {
 	schema := def.Type.(*ast.TypeSpec_Schema).Schema
-	extSchema := ext.Type.(*ast.TypeSpec_Schema).Schema.RootOps
+	extSchema := ext.Type.(*ast.TypeSpec_Schema).Schema
 
-	if extSchema != nil {
-		schema.RootOps.List = append(schema.RootOps.List, extSchema.List...)
+	if schema.RootOps == nil {
+		schema.RootOps = &ast.FieldList{}
 	}
+	schema.RootOps.List = mergeFields(schema.RootOps.List, extSchema.RootOps, "root operation", report)
 }
 
-func mergeObject(def, ext *ast.TypeSpec) {
+func mergeObject(def, ext *ast.TypeSpec, report func(string, ...interface{})) {
 	obj := def.Type.(*ast.TypeSpec_Object).Object
 	extObj := ext.Type.(*ast.TypeSpec_Object).Object
 
-	obj.Interfaces = append(obj.Interfaces, extObj.Interfaces...)
+	obj.Interfaces = mergeIdents(obj.Interfaces, extObj.Interfaces, "interface", report)
 
-	if extObj.Fields != nil {
-		obj.Fields.List = append(obj.Fields.List, extObj.Fields.List...)
+	if obj.Fields == nil {
+		obj.Fields = &ast.FieldList{}
 	}
+	obj.Fields.List = mergeFields(obj.Fields.List, extObj.Fields, "field", report)
 }
 
-func mergeInterface(def, ext *ast.TypeSpec) {
+func mergeInterface(def, ext *ast.TypeSpec, report func(string, ...interface{})) {
 	inter := def.Type.(*ast.TypeSpec_Interface).Interface
 	extInter := ext.Type.(*ast.TypeSpec_Interface).Interface
 
-	if extInter.Fields != nil {
-		inter.Fields.List = append(inter.Fields.List, extInter.Fields.List...)
+	if inter.Fields == nil {
+		inter.Fields = &ast.FieldList{}
 	}
+	inter.Fields.List = mergeFields(inter.Fields.List, extInter.Fields, "field", report)
 }
 
-func mergeUnion(def, ext *ast.TypeSpec) {
+func mergeUnion(def, ext *ast.TypeSpec, report func(string, ...interface{})) {
 	union := def.Type.(*ast.TypeSpec_Union).Union
 	extUnion := ext.Type.(*ast.TypeSpec_Union).Union
 
-	union.Members = append(union.Members, extUnion.Members...)
+	union.Members = mergeIdents(union.Members, extUnion.Members, "union member", report)
 }
 
-func mergeEnum(def, ext *ast.TypeSpec) {
+func mergeEnum(def, ext *ast.TypeSpec, report func(string, ...interface{})) {
 	enum := def.Type.(*ast.TypeSpec_Enum).Enum
 	extEnum := ext.Type.(*ast.TypeSpec_Enum).Enum
 
-	if extEnum.Values != nil {
-		enum.Values.List = append(enum.Values.List, extEnum.Values.List...)
+	if enum.Values == nil {
+		enum.Values = &ast.FieldList{}
 	}
+	enum.Values.List = mergeFields(enum.Values.List, extEnum.Values, "enum value", report)
 }
 
-func mergeInput(def, ext *ast.TypeSpec) {
+func mergeInput(def, ext *ast.TypeSpec, report func(string, ...interface{})) {
 	input := def.Type.(*ast.TypeSpec_Input).Input
 	extInput := ext.Type.(*ast.TypeSpec_Input).Input
 
-	if extInput.Fields != nil {
-		input.Fields.List = append(input.Fields.List, extInput.Fields.List...)
+	if input.Fields == nil {
+		input.Fields = &ast.InputValueList{}
+	}
+	if extInput.Fields == nil {
+		return
+	}
+
+	for _, ef := range extInput.Fields.List {
+		var of *ast.InputValue
+		for _, f := range input.Fields.List {
+			if f.Name.Name == ef.Name.Name {
+				of = f
+				break
+			}
+		}
+
+		switch {
+		case of == nil:
+			input.Fields.List = append(input.Fields.List, ef)
+		case TypeString(inputType(of)) != TypeString(inputType(ef)):
+			report("conflicting definition of input field: %s: %s != %s", ef.Name.Name, TypeString(inputType(of)), TypeString(inputType(ef)))
+		default:
+			report("duplicate input field: %s", ef.Name.Name)
+		}
+	}
+}
+
+// mergeFields appends the extension fields which aren't already declared.
+func mergeFields(fields []*ast.Field, ext *ast.FieldList, kind string, report func(string, ...interface{})) []*ast.Field {
+	if ext == nil {
+		return fields
+	}
+
+	for _, ef := range ext.List {
+		var of *ast.Field
+		for _, f := range fields {
+			if f.Name.Name == ef.Name.Name {
+				of = f
+				break
+			}
+		}
+
+		switch {
+		case of == nil:
+			fields = append(fields, ef)
+		case TypeString(fieldType(of)) != TypeString(fieldType(ef)):
+			report("conflicting definition of %s: %s: %s != %s", kind, ef.Name.Name, TypeString(fieldType(of)), TypeString(fieldType(ef)))
+		default:
+			report("duplicate %s: %s", kind, ef.Name.Name)
+		}
+	}
+	return fields
+}
+
+// mergeIdents appends the extension identifiers which aren't already declared.
+func mergeIdents(ids, ext []*ast.Ident, kind string, report func(string, ...interface{})) []*ast.Ident {
+	for _, e := range ext {
+		var exists bool
+		for _, id := range ids {
+			exists = exists || id.Name == e.Name
+		}
+		if exists {
+			report("duplicate %s: %s", kind, e.Name)
+			continue
+		}
+
+		ids = append(ids, e)
+	}
+	return ids
+}
+
+func fieldType(f *ast.Field) interface{} {
+	switch v := f.Type.(type) {
+	case *ast.Field_Ident:
+		return v.Ident
+	case *ast.Field_List:
+		return v.List
+	case *ast.Field_NonNull:
+		return v.NonNull
+	}
+	return nil
+}
+
+func inputType(v *ast.InputValue) interface{} {
+	switch x := v.Type.(type) {
+	case *ast.InputValue_Ident:
+		return x.Ident
+	case *ast.InputValue_List:
+		return x.List
+	case *ast.InputValue_NonNull:
+		return x.NonNull
 	}
+	return nil
 }
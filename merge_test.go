@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -147,7 +148,11 @@ extend input Test {
 				return
 			}
 
-			docIR := MergeExtensions(toDeclMap(doc.Types))
+			docIR, errs := MergeExtensions(toDeclMap(doc.Types))
+			if len(errs) > 0 {
+				subT.Errorf("unexpected errors: %v", errs)
+				return
+			}
 
 			for _, decls := range docIR {
 				if len(decls) > 1 {
@@ -166,3 +171,181 @@ extend input Test {
 		})
 	}
 }
+
+func TestMergeExtensionsErrors(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Input string
+		Errs  []string
+	}{
+		{
+			Name: "MismatchedExtension",
+			Input: `scalar Test
+
+extend enum Test {
+	A
+}`,
+			Errs: []string{
+				"compiler: merge error encountered in Test: cannot extend SCALAR with ENUM extension",
+			},
+		},
+		{
+			Name: "Redefinition",
+			Input: `scalar Test
+
+scalar Test`,
+			Errs: []string{
+				"compiler: merge error encountered in Test: cannot have more than one type definition",
+			},
+		},
+		{
+			Name: "DuplicateMembers",
+			Input: `type Test implements A @a {
+	a: A
+	b: B
+}
+
+extend type Test implements A & B @a {
+	a: A
+	b: [B]
+	c: C
+}
+
+union U = A | B
+
+extend union U = B | C
+
+enum E {
+	A
+}
+
+extend enum E {
+	A
+	B
+}
+
+input I {
+	a: A!
+}
+
+extend input I {
+	a: A
+}`,
+			Errs: []string{
+				"compiler: merge error encountered in Test: duplicate interface: A",
+				"compiler: merge error encountered in Test: duplicate field: a",
+				"compiler: merge error encountered in Test: conflicting definition of field: b: B != [B]",
+				"compiler: merge error encountered in Test: directive already applied: @a",
+				"compiler: merge error encountered in U: duplicate union member: B",
+				"compiler: merge error encountered in E: duplicate enum value: A",
+				"compiler: merge error encountered in I: conflicting definition of input field: a: A! != A",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), testCase.Name, strings.NewReader(testCase.Input), 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			_, errs := MergeExtensions(toDeclMap(doc.Types))
+			if len(errs) != len(testCase.Errs) {
+				subT.Errorf("expected %d errors but got: %v", len(testCase.Errs), errs)
+				return
+			}
+
+			for i, err := range errs {
+				if err.Error() != testCase.Errs[i] {
+					subT.Errorf("expected error: %s, but got: %s", testCase.Errs[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeExtensionsDocs(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`"A test type"
+type Test {
+	a: String
+}
+
+# Adds b
+extend type Test {
+	b: String
+}`), parser.ParseComments)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	types, errs := MergeExtensions(toDeclMap(doc.Types))
+	if len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+		return
+	}
+
+	decl := types["Test"][0]
+	if decl.Doc == nil || len(decl.Doc.List) != 2 {
+		t.Errorf("expected descriptions of both declarations but got: %v", decl.Doc)
+		return
+	}
+	if !strings.Contains(decl.Doc.List[1].Text, "Adds b") {
+		t.Errorf("expected extension comment but got: %s", decl.Doc.List[1].Text)
+	}
+}
+
+func TestMergeExtensionsContext(t *testing.T) {
+	src := `directive @tag(name: String) on OBJECT
+
+type Test @tag(name: "a") {
+	a: String
+}
+
+extend type Test @tag(name: "b")`
+
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(src), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, errs := MergeExtensions(toDeclMap(doc.Types))
+	if len(errs) != 1 || errs[0].Error() != "compiler: merge error encountered in Test: directive already applied: @tag" {
+		t.Errorf("expected directive conflict without a registry but got: %v", errs)
+	}
+
+	doc, err = parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(src), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRegistry(GlobalRegistry())
+	r.RegisterRepeatable("tag")
+
+	types, errs := MergeExtensionsContext(WithRegistry(context.Background(), r), toDeclMap(doc.Types))
+	if len(errs) != 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	if dirs := TypeSpecOf(types["Test"][0]).Directives; len(dirs) != 2 {
+		t.Errorf("expected both @tag applications but got: %v", dirs)
+	}
+}
+
+func TestMergeErrorPosition(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`scalar Test
+
+scalar Test`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, errs := MergeExtensions(toDeclMap(doc.Types))
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error but got: %v", errs)
+	}
+	if p := errs[0].(*MergeError).Position(); p.Type != "Test" || p.Pos != doc.Types[1].TokPos {
+		t.Errorf("expected position of the redefinition but got: %v", p)
+	}
+}
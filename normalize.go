@@ -0,0 +1,358 @@
+package compiler
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// rootOps contains the default root operation type names, per the GraphQL spec.
+var rootOps = []string{"Query", "Mutation", "Subscription"}
+
+// Normalize returns the canonical form of the IR, so that downstream type
+// checkers and generators can rely on a single shape instead of each handling
+// the raw one:
+//
+//   - type extensions are merged with their definitions, see MergeExtensions
+//   - if no schema is declared, a schema declaration is synthesized
+//   - default values are coerced to their input types, e.g. [Float] = 1
+//     becomes [Float] = [1.0]
+//   - descriptions are trimmed and joined into one per type, field, argument
+//     and enum value, see Description
+//   - implemented interfaces and union members are sorted by name, and
+//     directive locations in the order the spec lists them
+//   - the types of each Document, i.e. its Types, are sorted by kind and
+//     then by name, as FromIR sorts them
+//
+// Normalizing an already normalized IR is a no-op. Extensions are only merged
+// within a Document, so imports should be reduced first, see ReduceImports and
+// Flatten. Any MergeErrors are returned together, as a MultiError, along with
+// the IR.
+//
+// The schema is synthesized from the object types named Query, Mutation and
+// Subscription. It is added to the Document which declares the query type,
+// or the first root operation type found.
+//
+func Normalize(ir IR) (IR, error) {
+	var errs MultiError
+	for _, doc := range ir.Documents() {
+		types, merrs := MergeExtensions(ir[doc])
+		errs = append(errs, merrs...)
+		ir[doc] = types
+	}
+
+	implicitSchema(ir)
+
+	inputs := make(map[string]*ast.InputType)
+	for _, types := range ir {
+		for name, decls := range types {
+			if len(decls) == 0 {
+				continue
+			}
+
+			if input, ok := TypeSpecOf(decls[0]).Type.(*ast.TypeSpec_Input); ok {
+				inputs[name] = input.Input
+			}
+		}
+	}
+
+	for doc, types := range ir {
+		normalizeDoc(doc.Doc)
+
+		for _, decls := range types {
+			for _, decl := range decls {
+				normalizeDoc(decl.Doc)
+				normalizeSpec(TypeSpecOf(decl), inputs)
+			}
+		}
+	}
+	FromIR(ir)
+
+	return ir, errs.ErrorOrNil()
+}
+
+// implicitSchema synthesizes a schema declaration from the default root
+// operation types, if no schema is declared.
+//
+func implicitSchema(ir IR) {
+	for _, doc := range ir.Documents() {
+		if IsBuiltins(doc) {
+			continue
+		}
+
+		if _, ok := ir[doc]["schema"]; ok {
+			return
+		}
+	}
+
+	var schemaDoc *ast.Document
+	var ops []*ast.Field
+	for _, name := range rootOps {
+		doc := lookupRootOp(name, ir)
+		if doc == nil {
+			continue
+		}
+
+		if schemaDoc == nil {
+			schemaDoc = doc
+		}
+
+		ops = append(ops, &ast.Field{
+			Name: &ast.Ident{Name: strings.ToLower(name)},
+			Type: &ast.Field_Ident{Ident: &ast.Ident{Name: name}},
+		})
+	}
+	if schemaDoc == nil {
+		return
+	}
+
+	ir[schemaDoc]["schema"] = []*ast.TypeDecl{
+		{
+			Tok: token.Token_SCHEMA,
+			Spec: &ast.TypeDecl_TypeSpec{TypeSpec: &ast.TypeSpec{
+				Type: &ast.TypeSpec_Schema{Schema: &ast.SchemaType{
+					RootOps: &ast.FieldList{List: ops},
+				}},
+			}},
+		},
+	}
+}
+
+func normalizeSpec(ts *ast.TypeSpec, inputs map[string]*ast.InputType) {
+	var fields *ast.FieldList
+	var args *ast.InputValueList
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		fields = v.Schema.RootOps
+	case *ast.TypeSpec_Object:
+		sortIdents(v.Object.Interfaces)
+		fields = v.Object.Fields
+	case *ast.TypeSpec_Interface:
+		fields = v.Interface.Fields
+	case *ast.TypeSpec_Union:
+		sortIdents(v.Union.Members)
+	case *ast.TypeSpec_Enum:
+		fields = v.Enum.Values
+	case *ast.TypeSpec_Input:
+		args = v.Input.Fields
+	case *ast.TypeSpec_Directive:
+		sort.SliceStable(v.Directive.Locs, func(i, j int) bool {
+			return v.Directive.Locs[i].Loc < v.Directive.Locs[j].Loc
+		})
+		args = v.Directive.Args
+	}
+
+	if fields != nil {
+		for _, f := range fields.List {
+			normalizeDoc(f.Doc)
+			normalizeArgs(f.Args, inputs)
+		}
+	}
+	normalizeArgs(args, inputs)
+}
+
+func normalizeArgs(args *ast.InputValueList, inputs map[string]*ast.InputType) {
+	if args == nil {
+		return
+	}
+
+	for _, arg := range args.List {
+		normalizeDoc(arg.Doc)
+
+		var val *ast.CompositeLit
+		switch x := arg.Default.(type) {
+		case *ast.InputValue_BasicLit:
+			val = &ast.CompositeLit{Value: &ast.CompositeLit_BasicLit{BasicLit: x.BasicLit}}
+		case *ast.InputValue_CompositeLit:
+			val = x.CompositeLit
+		default:
+			continue
+		}
+
+		val = coerceValue(inputType(arg), val, inputs)
+		if b, ok := val.Value.(*ast.CompositeLit_BasicLit); ok {
+			arg.Default = &ast.InputValue_BasicLit{BasicLit: b.BasicLit}
+			continue
+		}
+		arg.Default = &ast.InputValue_CompositeLit{CompositeLit: val}
+	}
+}
+
+// coerceValue coerces a literal to the given input type, per the input
+// coercion rules of the GraphQL spec: Ints are coerced to Floats, single
+// values are coerced to lists and the fields of input objects are coerced
+// to their own types. Values which can't be coerced are left as is.
+//
+func coerceValue(t interface{}, val *ast.CompositeLit, inputs map[string]*ast.InputType) *ast.CompositeLit {
+	switch v := t.(type) {
+	case *ast.NonNull:
+		switch x := v.Type.(type) {
+		case *ast.NonNull_Ident:
+			return coerceValue(x.Ident, val, inputs)
+		case *ast.NonNull_List:
+			return coerceValue(x.List, val, inputs)
+		}
+	case *ast.List:
+		var elem interface{}
+		switch x := v.Type.(type) {
+		case *ast.List_Ident:
+			elem = x.Ident
+		case *ast.List_List:
+			elem = x.List
+		case *ast.List_NonNull:
+			elem = x.NonNull
+		}
+
+		var vals []*ast.CompositeLit
+		switch x := val.Value.(type) {
+		case *ast.CompositeLit_BasicLit:
+			if x.BasicLit.Kind == token.Token_NULL {
+				return val
+			}
+			vals = append(vals, val)
+		case *ast.CompositeLit_ObjLit:
+			vals = append(vals, val)
+		case *ast.CompositeLit_ListLit:
+			switch y := x.ListLit.List.(type) {
+			case *ast.ListLit_BasicList:
+				for _, b := range y.BasicList.Values {
+					vals = append(vals, &ast.CompositeLit{Value: &ast.CompositeLit_BasicLit{BasicLit: b}})
+				}
+			case *ast.ListLit_CompositeList:
+				vals = y.CompositeList.Values
+			}
+		}
+
+		basics := make([]*ast.BasicLit, 0, len(vals))
+		for i, c := range vals {
+			vals[i] = coerceValue(elem, c, inputs)
+			if b, ok := vals[i].Value.(*ast.CompositeLit_BasicLit); ok {
+				basics = append(basics, b.BasicLit)
+			}
+		}
+
+		list := &ast.ListLit{List: &ast.ListLit_CompositeList{CompositeList: &ast.ListLit_Composite{Values: vals}}}
+		if len(basics) == len(vals) {
+			list.List = &ast.ListLit_BasicList{BasicList: &ast.ListLit_Basic{Values: basics}}
+		}
+		return &ast.CompositeLit{Value: &ast.CompositeLit_ListLit{ListLit: list}}
+	case *ast.Ident:
+		switch x := val.Value.(type) {
+		case *ast.CompositeLit_BasicLit:
+			if v.Name == "Float" && x.BasicLit.Kind == token.Token_INT {
+				x.BasicLit.Kind = token.Token_FLOAT
+				x.BasicLit.Value += ".0"
+			}
+		case *ast.CompositeLit_ObjLit:
+			input, ok := inputs[v.Name]
+			if !ok || input.Fields == nil {
+				break
+			}
+
+			for _, p := range x.ObjLit.Fields {
+				for _, f := range input.Fields.List {
+					if f.Name.Name != p.Key.Name {
+						continue
+					}
+
+					p.Val = coerceValue(inputType(f), p.Val, inputs)
+				}
+			}
+		}
+	}
+	return val
+}
+
+// normalizeDoc replaces the descriptions of a DocGroup with their trimmed
+// text, as a single description. Comments are kept as is.
+//
+func normalizeDoc(g *ast.DocGroup) {
+	if g == nil {
+		return
+	}
+
+	desc := Description(g)
+
+	list := g.List[:0]
+	var char int64
+	for _, d := range g.List {
+		if d.Comment {
+			list = append(list, d)
+			continue
+		}
+
+		if char == 0 {
+			char = d.Char
+		}
+	}
+	if desc != "" {
+		list = append(list, &ast.DocGroup_Doc{Text: descriptionLit(desc), Char: char})
+	}
+	g.List = list
+}
+
+// descriptionLit returns the string literal of a description: a string for
+// single lines and a block string otherwise.
+//
+func descriptionLit(s string) string {
+	if !strings.Contains(s, "\n") && !strings.Contains(s, `"`) && !strings.Contains(s, `\`) {
+		return `"` + s + `"`
+	}
+	return `"""` + "\n" + strings.Replace(s, `"""`, `\"""`, -1) + "\n" + `"""`
+}
+
+func sortIdents(ids []*ast.Ident) {
+	sort.SliceStable(ids, func(i, j int) bool { return ids[i].Name < ids[j].Name })
+}
+
+// lookupRootOp returns the Document which declares the named object type.
+func lookupRootOp(name string, ir IR) *ast.Document {
+	for _, doc := range ir.Documents() {
+		decls, ok := ir[doc][name]
+		if !ok || len(decls) == 0 || DeclTok(decls[0]) != token.Token_TYPE {
+			continue
+		}
+
+		return doc
+	}
+	return nil
+}
+
+// RootOperations returns the root operation types of the IR, mapping each
+// type name to the operation it's used for, i.e. query, mutation or
+// subscription. The declared schema, and its extensions, are used when
+// present; otherwise, the default root operation type names are.
+//
+func RootOperations(ir IR) map[string]string {
+	ops := make(map[string]string)
+
+	var declared bool
+	for _, doc := range ir.Documents() {
+		for _, decl := range ir[doc]["schema"] {
+			declared = true
+
+			schema, ok := TypeSpecOf(decl).Type.(*ast.TypeSpec_Schema)
+			if !ok || schema.Schema.RootOps == nil {
+				continue
+			}
+
+			for _, f := range schema.Schema.RootOps.List {
+				if id, ok := f.Type.(*ast.Field_Ident); ok {
+					ops[id.Ident.Name] = f.Name.Name
+				}
+			}
+		}
+	}
+	if declared {
+		return ops
+	}
+
+	for _, name := range rootOps {
+		if lookupRootOp(name, ir) != nil {
+			ops[name] = strings.ToLower(name)
+		}
+	}
+	return ops
+}
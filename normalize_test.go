@@ -0,0 +1,269 @@
+package compiler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestNormalize(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Src  string
+		Ops  []string
+	}{
+		{
+			Name: "DeclaredSchema",
+			Src: `schema {
+	query: Root
+}
+
+type Root {
+	a: String
+}
+
+type Mutation {
+	a: String
+}`,
+			Ops: []string{"query:Root"},
+		},
+		{
+			Name: "ImplicitSchema",
+			Src: `type Subscription {
+	a: String
+}
+
+type Query {
+	a: String
+}`,
+			Ops: []string{"query:Query", "subscription:Subscription"},
+		},
+		{
+			Name: "NonObjectRoot",
+			Src: `type Query {
+	a: String
+}
+
+scalar Mutation`,
+			Ops: []string{"query:Query"},
+		},
+		{
+			Name: "NoRoots",
+			Src:  `scalar String`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), testCase.Name, strings.NewReader(testCase.Src), 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			ir, err := Normalize(ToIR([]*ast.Document{doc}))
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			decls, ok := ir[doc]["schema"]
+			if !ok {
+				if len(testCase.Ops) > 0 {
+					subT.Error("expected schema to be declared")
+				}
+				return
+			}
+
+			var ops []string
+			for _, f := range TypeSpecOf(decls[0]).Type.(*ast.TypeSpec_Schema).Schema.RootOps.List {
+				ops = append(ops, f.Name.Name+":"+f.Type.(*ast.Field_Ident).Ident.Name)
+			}
+
+			if strings.Join(ops, ",") != strings.Join(testCase.Ops, ",") {
+				subT.Errorf("expected root operations: %v, but got: %v", testCase.Ops, ops)
+			}
+		})
+	}
+}
+
+func TestNormalizeCanonical(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "a", strings.NewReader(`"""
+    The root query type.
+"""
+type Query implements Node & Entity {
+	"   The user.  "
+	user(id: [ID] = 1, scale: Float = 2, filter: Filter = {ids: 1, min: 0}): User
+}
+
+extend type Query {
+	users: [User]
+}
+
+type User {
+	id: ID
+}
+
+union Result = User | Query
+
+input Filter {
+	ids: [ID]
+	min: Float
+}
+
+directive @tag on OBJECT | FIELD_DEFINITION | SCALAR`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `"The root query type."
+type Query implements Entity & Node {
+	"The user."
+	user(id: [ID] = [1], scale: Float = 2.0, filter: Filter = {ids: [1], min: 0.0}): User
+	users: [User]
+}
+
+type User {
+	id: ID
+}
+
+union Result = Query | User
+
+input Filter {
+	ids: [ID]
+	min: Float
+}
+
+directive @tag on SCALAR | OBJECT | FIELD_DEFINITION
+
+schema {
+	query: Query
+}
+`
+
+	ir := ToIR([]*ast.Document{doc})
+	for i := 0; i < 2; i++ {
+		var err error
+		ir, err = Normalize(ir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var b bytes.Buffer
+		if err := WriteSDL(&b, FromIRInOrder(ir)[0]); err != nil {
+			t.Fatal(err)
+		}
+
+		if b.String() != expected {
+			t.Fatalf("expected normalization %d to be:\n%s\nbut got:\n%s", i+1, expected, b.String())
+		}
+	}
+
+	ir, err = Normalize(ir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, decl := range doc.Types {
+		names = append(names, declName(decl))
+	}
+	if s := strings.Join(names, " "); s != "schema Query User Result Filter tag" {
+		t.Errorf("expected types to be sorted by kind and name but got: %s", s)
+	}
+}
+
+func TestRootOperations(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Src  string
+		Ops  map[string]string
+	}{
+		{
+			Name: "Declared",
+			Src: `schema {
+	query: RootQuery
+}
+
+extend schema {
+	mutation: RootMutation
+}
+
+type RootQuery {
+	a: String
+}
+
+type RootMutation {
+	a: String
+}
+
+type Query {
+	a: String
+}`,
+			Ops: map[string]string{"RootQuery": "query", "RootMutation": "mutation"},
+		},
+		{
+			Name: "Implicit",
+			Src: `type Query {
+	a: String
+}
+
+type Subscription {
+	a: String
+}`,
+			Ops: map[string]string{"Query": "query", "Subscription": "subscription"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), testCase.Name, strings.NewReader(testCase.Src), 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			ops := RootOperations(ToIR([]*ast.Document{doc}))
+			if len(ops) != len(testCase.Ops) {
+				subT.Errorf("expected root operations: %v, but got: %v", testCase.Ops, ops)
+				return
+			}
+
+			for name, op := range testCase.Ops {
+				if ops[name] != op {
+					subT.Errorf("expected %s to be the %s type, but got: %s", name, op, ops[name])
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeUnmerged(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "a", strings.NewReader(`type Query {
+	a: Int
+}
+
+extend type Foo {
+	b: Int
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Foo may be defined by another Document, so it's left unmerged
+	if _, err = Normalize(ToIR([]*ast.Document{doc})); err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, decl := range doc.Types {
+		names = append(names, declName(decl))
+	}
+	if s := strings.Join(names, " "); s != "schema Foo Query" {
+		t.Errorf("expected unmerged extension to be sorted with the objects but got: %s", s)
+	}
+}
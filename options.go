@@ -0,0 +1,148 @@
+package compiler
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// Options represents the options given to a generator.
+type Options map[string]interface{}
+
+// ParseOptions parses generator options given as either a JSON object,
+// e.g. {"title": "My API", "html": true}, or protoc style comma-separated
+// key=value pairs, e.g. title=My API,html. Both forms are normalized to the
+// same structure: a key without a value is true, and values which look like
+// booleans or numbers are decoded as they would be from JSON.
+//
+func ParseOptions(s string) (Options, error) {
+	opts := make(Options)
+
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return opts, nil
+	}
+
+	if strings.HasPrefix(s, "{") {
+		if err := json.Unmarshal([]byte(s), &opts); err != nil {
+			return nil, fmt.Errorf("compiler: malformed options: %s", err)
+		}
+		return opts, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+
+		key := strings.TrimSpace(kv[0])
+		if key == "" {
+			return nil, fmt.Errorf("compiler: malformed options: missing key in: %q", pair)
+		}
+		if _, exists := opts[key]; exists {
+			return nil, fmt.Errorf("compiler: malformed options: duplicate key: %s", key)
+		}
+
+		if len(kv) == 1 {
+			opts[key] = true
+			continue
+		}
+
+		opts[key] = optionValue(strings.TrimSpace(kv[1]))
+	}
+	return opts, nil
+}
+
+// jsonNumber matches the JSON number syntax, since strconv.ParseFloat
+// also accepts e.g. "inf", "nan" or "0x1p4", which JSON can't represent.
+//
+var jsonNumber = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+func optionValue(v string) interface{} {
+	if v == "true" || v == "false" {
+		return v == "true"
+	}
+	if !jsonNumber.MatchString(v) {
+		return v
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f
+	}
+	return v
+}
+
+// DocOptions returns the generator options declared by a Document with the
+// @gqlc directive, which lets individual Documents customize generators
+// without changing the global invocation:
+//
+// @gqlc(for: "doc", title: "Users API")
+// @gqlc(package: "users")
+//
+// Directives without a for argument apply to every generator; options
+// declared for the named generator take precedence over them.
+//
+func DocOptions(doc *ast.Document, generator string) Options {
+	var general, specific []*ast.DirectiveLit
+	for _, dir := range doc.Directives {
+		if dir.Name != "gqlc" || dir.Args == nil {
+			continue
+		}
+
+		var target string
+		for _, arg := range dir.Args.Args {
+			if arg.Name != nil && arg.Name.Name == "for" {
+				target = strings.Join(ArgStrings(arg), "")
+			}
+		}
+
+		switch target {
+		case "":
+			general = append(general, dir)
+		case generator:
+			specific = append(specific, dir)
+		}
+	}
+
+	opts := make(Options)
+	for _, dir := range append(general, specific...) {
+		for _, arg := range dir.Args.Args {
+			if arg.Name == nil || arg.Name.Name == "for" {
+				continue
+			}
+
+			opts[arg.Name.Name] = argOption(arg)
+		}
+	}
+	return opts
+}
+
+// StripOptions removes every @gqlc directive from the Documents in the IR,
+// since its arguments are free-form and wouldn't pass type checking.
+//
+func StripOptions(ir IR) {
+	for doc := range ir {
+		dirs := doc.Directives[:0]
+		for _, d := range doc.Directives {
+			if d.Name != "gqlc" {
+				dirs = append(dirs, d)
+			}
+		}
+		doc.Directives = dirs
+	}
+}
+
+func argOption(arg *ast.Arg) interface{} {
+	switch v := arg.Value.(type) {
+	case *ast.Arg_BasicLit:
+		if v.BasicLit.Kind == token.Token_STRING {
+			return strings.Trim(v.BasicLit.Value, "\"")
+		}
+		return optionValue(v.BasicLit.Value)
+	case *ast.Arg_CompositeLit:
+		return ValueString(v.CompositeLit)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package compiler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestParseOptions(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Src      string
+		Expected Options
+		Err      string
+	}{
+		{
+			Name:     "Empty",
+			Src:      "  ",
+			Expected: Options{},
+		},
+		{
+			Name:     "JSON",
+			Src:      `{"title": "My API", "html": true, "depth": 2}`,
+			Expected: Options{"title": "My API", "html": true, "depth": float64(2)},
+		},
+		{
+			Name:     "KeyValue",
+			Src:      `title=My API,html,depth=2,draft=false`,
+			Expected: Options{"title": "My API", "html": true, "depth": float64(2), "draft": false},
+		},
+		{
+			Name:     "NonJSONNumbers",
+			Src:      `a=inf,b=NaN,c=-Infinity,d=0x10,e=1_000,f=.5,g=-1.5e3`,
+			Expected: Options{"a": "inf", "b": "NaN", "c": "-Infinity", "d": "0x10", "e": "1_000", "f": ".5", "g": float64(-1500)},
+		},
+		{
+			Name: "MalformedJSON",
+			Src:  `{"title": }`,
+			Err:  "compiler: malformed options: invalid character '}' looking for beginning of value",
+		},
+		{
+			Name: "MissingKey",
+			Src:  `title=a,=b`,
+			Err:  `compiler: malformed options: missing key in: "=b"`,
+		},
+		{
+			Name: "DuplicateKey",
+			Src:  `html,html=false`,
+			Err:  "compiler: malformed options: duplicate key: html",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			opts, err := ParseOptions(testCase.Src)
+			if err != nil {
+				if err.Error() != testCase.Err {
+					subT.Errorf("expected error: %s, but got: %s", testCase.Err, err)
+				}
+				return
+			}
+			if testCase.Err != "" {
+				subT.Errorf("expected error: %s", testCase.Err)
+				return
+			}
+
+			if !reflect.DeepEqual(opts, testCase.Expected) {
+				subT.Errorf("expected options: %v, but got: %v", testCase.Expected, opts)
+			}
+		})
+	}
+}
+
+func TestDocOptions(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`@import(paths: ["a"])
+@gqlc(title: "Users", depth: 2, html: true)
+@gqlc(for: "doc", title: "Users API", tags: ["a", "b"])
+@gqlc(for: "go", package: "users")
+
+scalar A`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	testCases := []struct {
+		Name     string
+		Expected Options
+	}{
+		{
+			Name:     "doc",
+			Expected: Options{"title": "Users API", "depth": float64(2), "html": true, "tags": `["a", "b"]`},
+		},
+		{
+			Name:     "go",
+			Expected: Options{"title": "Users", "depth": float64(2), "html": true, "package": "users"},
+		},
+		{
+			Name:     "js",
+			Expected: Options{"title": "Users", "depth": float64(2), "html": true},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			opts := DocOptions(doc, testCase.Name)
+			if !reflect.DeepEqual(opts, testCase.Expected) {
+				subT.Errorf("expected options: %v, but got: %v", testCase.Expected, opts)
+			}
+		})
+	}
+
+	StripOptions(ToIR([]*ast.Document{doc}))
+	if len(doc.Directives) != 1 || doc.Directives[0].Name != "import" {
+		t.Errorf("expected only @gqlc directives to be removed but got: %v", doc.Directives)
+	}
+}
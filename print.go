@@ -0,0 +1,185 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// ValueString returns the GraphQL notation of a literal value, e.g. ["a", "b"]
+// or {a: 1, b: [true]}. It accepts any of the literal nodes found in directive
+// arguments and default values: *ast.BasicLit, *ast.CompositeLit, *ast.ListLit
+// and *ast.ObjLit.
+//
+func ValueString(v interface{}) string {
+	switch x := v.(type) {
+	case *ast.BasicLit:
+		return x.Value
+	case *ast.CompositeLit:
+		switch y := x.Value.(type) {
+		case *ast.CompositeLit_BasicLit:
+			return ValueString(y.BasicLit)
+		case *ast.CompositeLit_ListLit:
+			return ValueString(y.ListLit)
+		case *ast.CompositeLit_ObjLit:
+			return ValueString(y.ObjLit)
+		}
+	case *ast.ListLit:
+		var vals []string
+		switch y := x.List.(type) {
+		case *ast.ListLit_BasicList:
+			for _, b := range y.BasicList.Values {
+				vals = append(vals, ValueString(b))
+			}
+		case *ast.ListLit_CompositeList:
+			for _, c := range y.CompositeList.Values {
+				vals = append(vals, ValueString(c))
+			}
+		}
+		return "[" + strings.Join(vals, ", ") + "]"
+	case *ast.ObjLit:
+		var pairs []string
+		for _, p := range x.Fields {
+			pairs = append(pairs, p.Key.Name+": "+ValueString(p.Val))
+		}
+		return "{" + strings.Join(pairs, ", ") + "}"
+	}
+	return ""
+}
+
+// DefaultString returns the GraphQL notation of an input value's
+// default value, or an empty string if it doesn't have one.
+//
+func DefaultString(v *ast.InputValue) string {
+	switch x := v.Default.(type) {
+	case *ast.InputValue_BasicLit:
+		return ValueString(x.BasicLit)
+	case *ast.InputValue_CompositeLit:
+		return ValueString(x.CompositeLit)
+	}
+	return ""
+}
+
+// DirectiveString returns the GraphQL notation of an applied directive,
+// including its arguments, e.g. @import(paths: ["a", "b"])
+//
+func DirectiveString(d *ast.DirectiveLit) string {
+	s := "@" + d.Name
+	if d.Args == nil || len(d.Args.Args) == 0 {
+		return s
+	}
+
+	args := make([]string, 0, len(d.Args.Args))
+	for _, arg := range d.Args.Args {
+		var val string
+		switch v := arg.Value.(type) {
+		case *ast.Arg_BasicLit:
+			val = ValueString(v.BasicLit)
+		case *ast.Arg_CompositeLit:
+			val = ValueString(v.CompositeLit)
+		}
+
+		if arg.Name == nil {
+			args = append(args, val)
+			continue
+		}
+		args = append(args, arg.Name.Name+": "+val)
+	}
+	return s + "(" + strings.Join(args, ", ") + ")"
+}
+
+// TypeString returns the GraphQL notation of a type, e.g. [String!]!
+// It accepts any of the type nodes: *ast.Ident, *ast.List and *ast.NonNull.
+//
+func TypeString(t interface{}) string {
+	switch v := t.(type) {
+	case *ast.Ident:
+		return v.Name
+	case *ast.List:
+		switch u := v.Type.(type) {
+		case *ast.List_Ident:
+			return "[" + TypeString(u.Ident) + "]"
+		case *ast.List_List:
+			return "[" + TypeString(u.List) + "]"
+		case *ast.List_NonNull:
+			return "[" + TypeString(u.NonNull) + "]"
+		}
+	case *ast.NonNull:
+		switch u := v.Type.(type) {
+		case *ast.NonNull_Ident:
+			return TypeString(u.Ident) + "!"
+		case *ast.NonNull_List:
+			return TypeString(u.List) + "!"
+		}
+	}
+	return ""
+}
+
+// ParseType parses the GraphQL notation of a type, e.g. [String!]!, and
+// is the inverse of TypeString. The returned type is one of: *ast.Ident,
+// *ast.List or *ast.NonNull.
+//
+func ParseType(s string) (interface{}, error) {
+	t, rest, ok := parseType(s)
+	if !ok || strings.TrimSpace(rest) != "" {
+		return nil, fmt.Errorf("compiler: malformed type: %q", s)
+	}
+	return t, nil
+}
+
+func parseType(s string) (t interface{}, rest string, ok bool) {
+	s = strings.TrimLeft(s, " \t")
+	if strings.HasPrefix(s, "[") {
+		var elem interface{}
+		elem, rest, ok = parseType(s[1:])
+		rest = strings.TrimLeft(rest, " \t")
+		if !ok || !strings.HasPrefix(rest, "]") {
+			return nil, s, false
+		}
+
+		l := &ast.List{}
+		switch v := elem.(type) {
+		case *ast.Ident:
+			l.Type = &ast.List_Ident{Ident: v}
+		case *ast.List:
+			l.Type = &ast.List_List{List: v}
+		case *ast.NonNull:
+			l.Type = &ast.List_NonNull{NonNull: v}
+		}
+		t, rest = l, rest[1:]
+	} else {
+		i := 0
+		for i < len(s) && isNameChar(s[i], i == 0) {
+			i++
+		}
+		if i == 0 {
+			return nil, s, false
+		}
+		t, rest = &ast.Ident{Name: s[:i]}, s[i:]
+	}
+
+	rest = strings.TrimLeft(rest, " \t")
+	if !strings.HasPrefix(rest, "!") {
+		return t, rest, true
+	}
+
+	switch v := t.(type) {
+	case *ast.Ident:
+		t = &ast.NonNull{Type: &ast.NonNull_Ident{Ident: v}}
+	case *ast.List:
+		t = &ast.NonNull{Type: &ast.NonNull_List{List: v}}
+	}
+	return t, rest[1:], true
+}
+
+// isNameChar reports whether c may be used in a GraphQL name.
+func isNameChar(c byte, first bool) bool {
+	switch {
+	case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		return true
+	case '0' <= c && c <= '9':
+		return !first
+	}
+	return false
+}
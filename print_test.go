@@ -0,0 +1,119 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestDirectiveString(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Src      string
+		Expected string
+	}{
+		{
+			Name:     "NoArgs",
+			Src:      `scalar Test @a`,
+			Expected: `@a`,
+		},
+		{
+			Name:     "BasicLit",
+			Src:      `scalar Test @a(b: "c", d: 1)`,
+			Expected: `@a(b: "c", d: 1)`,
+		},
+		{
+			Name:     "List",
+			Src:      `scalar Test @import(paths: ["a", "b"])`,
+			Expected: `@import(paths: ["a", "b"])`,
+		},
+		{
+			Name:     "Object",
+			Src:      `scalar Test @a(b: {c: 1, d: [true, false]})`,
+			Expected: `@a(b: {c: 1, d: [true, false]})`,
+		},
+		{
+			Name:     "Nested",
+			Src:      `scalar Test @a(b: [{c: [[1], [2]]}, {c: []}])`,
+			Expected: `@a(b: [{c: [[1], [2]]}, {c: []}])`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), testCase.Name, strings.NewReader(testCase.Src), 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			d := doc.Types[0].Spec.(*ast.TypeDecl_TypeSpec).TypeSpec.Directives[0]
+			if s := DirectiveString(d); s != testCase.Expected {
+				subT.Errorf("expected: %s, but got: %s", testCase.Expected, s)
+			}
+		})
+	}
+}
+
+func TestDefaultString(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`input Test {
+	a: Int = 1
+	b: [String] = ["x", "y"]
+	c: Obj = {d: {e: 2}}
+	f: Int
+}`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{`1`, `["x", "y"]`, `{d: {e: 2}}`, ``}
+	fields := doc.Types[0].Spec.(*ast.TypeDecl_TypeSpec).TypeSpec.Type.(*ast.TypeSpec_Input).Input.Fields.List
+	for i, f := range fields {
+		if s := DefaultString(f); s != expected[i] {
+			t.Errorf("expected: %s, but got: %s", expected[i], s)
+		}
+	}
+}
+
+func TestTypeString(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Src      string
+		Expected string
+		Err      bool
+	}{
+		{Name: "Ident", Src: "Int", Expected: "Int"},
+		{Name: "NonNull", Src: "Int!", Expected: "Int!"},
+		{Name: "List", Src: "[Int]", Expected: "[Int]"},
+		{Name: "Nested", Src: "[[Int!]]!", Expected: "[[Int!]]!"},
+		{Name: "Spaces", Src: " [ Int ! ] ! ", Expected: "[Int!]!"},
+		{Name: "Empty", Src: "", Err: true},
+		{Name: "Unclosed", Src: "[Int", Err: true},
+		{Name: "DoubleNonNull", Src: "Int!!", Err: true},
+		{Name: "InvalidName", Src: "1nt", Err: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			typ, err := ParseType(testCase.Src)
+			if testCase.Err {
+				if err == nil {
+					subT.Errorf("expected error but got: %s", TypeString(typ))
+				}
+				return
+			}
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			if s := TypeString(typ); s != testCase.Expected {
+				subT.Errorf("expected: %s but got: %s", testCase.Expected, s)
+			}
+		})
+	}
+}
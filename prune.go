@@ -0,0 +1,111 @@
+package compiler
+
+import (
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// PruneMode controls the behaviour of Prune.
+type PruneMode uint
+
+const (
+	// KeepDirectives keeps every directive definition, along with any
+	// types which are only reachable through their arguments.
+	KeepDirectives PruneMode = 1 << iota
+)
+
+// Prune removes every type which isn't reachable from the schema root
+// operations. If no schema is declared, the conventional root types:
+// Query, Mutation and Subscription, are used.
+//
+// Types reachable from a kept type include: field, argument and union
+// member types, implemented interfaces, implementations of interfaces,
+// and the definitions of any applied directives.
+//
+func Prune(ir IR, mode PruneMode) IR {
+	reachable := reachableTypes(ir, mode)
+	for _, mdecls := range ir {
+		for name := range mdecls {
+			if !reachable[name] {
+				delete(mdecls, name)
+			}
+		}
+	}
+
+	return ir
+}
+
+// reachableTypes returns the names of the types, and directives, which are
+// reachable from the schema root operations, as described by Prune.
+//
+func reachableTypes(ir IR, mode PruneMode) map[string]bool {
+	index := make(map[string][]*ast.TypeDecl)
+	implementors := make(map[string][]string)
+	for _, mdecls := range ir {
+		for name, decls := range mdecls {
+			index[name] = append(index[name], decls...)
+
+			for _, decl := range decls {
+				obj, ok := TypeSpecOf(decl).Type.(*ast.TypeSpec_Object)
+				if !ok {
+					continue
+				}
+
+				for _, i := range obj.Object.Interfaces {
+					implementors[i.Name] = append(implementors[i.Name], name)
+				}
+			}
+		}
+	}
+
+	reachable := make(map[string]bool, len(index))
+	var q []string
+	mark := func(name string) {
+		if reachable[name] {
+			return
+		}
+
+		reachable[name] = true
+		q = append(q, name)
+	}
+
+	// Mark roots
+	if _, ok := index["schema"]; ok {
+		mark("schema")
+	} else {
+		mark("Query")
+		mark("Mutation")
+		mark("Subscription")
+	}
+	for doc := range ir {
+		for _, d := range doc.Directives {
+			mark(d.Name)
+		}
+	}
+	if mode&KeepDirectives != 0 {
+		for name, decls := range index {
+			if DeclTok(decls[0]) == token.Token_DIRECTIVE {
+				mark(name)
+			}
+		}
+	}
+
+	// Walk references
+	for len(q) > 0 {
+		name := q[0]
+		q = q[1:]
+
+		for _, decl := range index[name] {
+			ts := TypeSpecOf(decl)
+
+			walkRefs(ts, func(_ string, id *ast.Ident) { mark(id.Name) })
+			walkDirectives(ts, func(_ string, d *ast.DirectiveLit) { mark(d.Name) })
+		}
+
+		for _, impl := range implementors[name] {
+			mark(impl)
+		}
+	}
+
+	return reachable
+}
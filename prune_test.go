@@ -0,0 +1,115 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestPrune(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Src   string
+		Mode  PruneMode
+		Types []string
+	}{
+		{
+			Name: "Schema",
+			Src: `schema {
+	query: Root
+}
+
+type Root {
+	node(id: ID!): Node
+}
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+}
+
+type Unused {
+	id: ID!
+}`,
+			Types: []string{"schema", "Root", "Node", "User"},
+		},
+		{
+			Name: "NoSchema",
+			Src: `type Query {
+	search(filter: Filter): [Result!]!
+}
+
+input Filter {
+	text: String
+}
+
+union Result = Page
+
+type Page {
+	title: String
+}
+
+scalar Unused`,
+			Types: []string{"Query", "Filter", "Result", "Page"},
+		},
+		{
+			Name: "AppliedDirectives",
+			Src: `type Query {
+	a: String @auth(role: ADMIN)
+}
+
+directive @auth(role: Role) on FIELD_DEFINITION
+
+enum Role {
+	ADMIN
+}
+
+directive @cache(ttl: Duration) on FIELD_DEFINITION
+
+scalar Duration`,
+			Types: []string{"Query", "auth", "Role"},
+		},
+		{
+			Name: "KeepDirectives",
+			Src: `type Query {
+	a: String
+}
+
+directive @cache(ttl: Duration) on FIELD
+
+scalar Duration
+
+scalar Unused`,
+			Mode:  KeepDirectives,
+			Types: []string{"Query", "cache", "Duration"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), testCase.Name, strings.NewReader(testCase.Src), 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			types := Prune(ToIR([]*ast.Document{doc}), testCase.Mode)[doc]
+			if len(types) != len(testCase.Types) {
+				subT.Errorf("expected %d types but got: %d", len(testCase.Types), len(types))
+				return
+			}
+
+			for _, name := range testCase.Types {
+				if _, ok := types[name]; !ok {
+					subT.Errorf("expected type to be kept: %s", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package compiler
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// Registry holds the pre-defined types and repeatable directives known to
+// a compilation. Registries can be attached to a compilation with
+// WithRegistry, so concurrent compilations, and tests, don't need to share
+// the global registry, i.e. Types and the directives given to
+// RegisterRepeatable.
+//
+// A Registry extends its parent. Types registered with a Registry take
+// precedence over any types of the same name known to its parent, so
+// builtins, e.g. scalars, can be overridden per compilation.
+//
+// Repeatable directives and directive handlers may be registered while
+// the Registry is in use by concurrent compilations.
+//
+type Registry struct {
+	parent *Registry
+	types  []*ast.TypeDecl
+
+	mu         sync.RWMutex
+	repeatable map[string]bool
+	handlers   map[string]DirectiveHandler
+}
+
+// global is the registry backed by Types and RegisterRepeatable.
+var global = &Registry{
+	repeatable: map[string]bool{"import": true},
+	handlers:   make(map[string]DirectiveHandler),
+}
+
+// GlobalRegistry returns the global registry. Types registered with it
+// are appended to Types.
+//
+func GlobalRegistry() *Registry { return global }
+
+// NewRegistry returns an empty Registry which extends parent. If parent
+// is nil, the Registry is isolated from the global registry.
+//
+func NewRegistry(parent *Registry) *Registry {
+	return &Registry{
+		parent:     parent,
+		repeatable: make(map[string]bool),
+		handlers:   make(map[string]DirectiveHandler),
+	}
+}
+
+// RegisterTypes registers pre-defined types with the Registry, replacing
+// any types of the same name it already has.
+//
+func (r *Registry) RegisterTypes(decls ...*ast.TypeDecl) {
+	if r == global {
+		RegisterTypes(decls...)
+		return
+	}
+
+	for _, decl := range decls {
+		name := declName(decl)
+
+		l := r.types[:0]
+		for _, d := range r.types {
+			if declName(d) != name {
+				l = append(l, d)
+			}
+		}
+		r.types = append(l, decl)
+	}
+}
+
+// RegisterRepeatable marks the named directives as repeatable.
+func (r *Registry) RegisterRepeatable(names ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range names {
+		r.repeatable[name] = true
+	}
+}
+
+// IsRepeatable reports whether the named directive is repeatable.
+func (r *Registry) IsRepeatable(name string) bool {
+	for ; r != nil; r = r.parent {
+		r.mu.RLock()
+		ok := r.repeatable[name]
+		r.mu.RUnlock()
+
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterDirectiveHandler registers the handler for the named directive,
+// replacing any handler already registered with the Registry for it.
+//
+func (r *Registry) RegisterDirectiveHandler(name string, h DirectiveHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[name] = h
+}
+
+// DirectiveHandler returns the handler for the named directive, or nil.
+func (r *Registry) DirectiveHandler(name string) DirectiveHandler {
+	for ; r != nil; r = r.parent {
+		r.mu.RLock()
+		h, ok := r.handlers[name]
+		r.mu.RUnlock()
+
+		if ok {
+			return h
+		}
+	}
+	return nil
+}
+
+// Types returns the types known to the Registry, including those of
+// its parent which haven't been overridden.
+//
+func (r *Registry) Types() []*ast.TypeDecl {
+	if r == global {
+		return Types
+	}
+
+	var types []*ast.TypeDecl
+	if r.parent != nil {
+		own := make(map[string]bool, len(r.types))
+		for _, decl := range r.types {
+			own[declName(decl)] = true
+		}
+
+		for _, decl := range r.parent.Types() {
+			if !own[declName(decl)] {
+				types = append(types, decl)
+			}
+		}
+	}
+	return append(types, r.types...)
+}
+
+type registryKey struct{}
+
+// WithRegistry returns a copy of ctx which carries the Registry. The
+// context aware functions, e.g. CheckTypesContext, use it in place of
+// the global registry.
+//
+func WithRegistry(ctx context.Context, r *Registry) context.Context {
+	return context.WithValue(ctx, registryKey{}, r)
+}
+
+// RegistryFrom returns the Registry carried by ctx, or the global
+// registry if there isn't one.
+//
+func RegistryFrom(ctx context.Context) *Registry {
+	if r, ok := ctx.Value(registryKey{}).(*Registry); ok && r != nil {
+		return r
+	}
+	return global
+}
+
+// declName returns the name of a declaration, or "schema".
+func declName(decl *ast.TypeDecl) string {
+	if ts := TypeSpecOf(decl); ts != nil && ts.Name != nil {
+		return ts.Name.Name
+	}
+	return "schema"
+}
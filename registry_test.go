@@ -0,0 +1,105 @@
+package compiler
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+func scalarDecl(name string) *ast.TypeDecl {
+	return &ast.TypeDecl{
+		Tok: token.Token_SCALAR,
+		Spec: &ast.TypeDecl_TypeSpec{TypeSpec: &ast.TypeSpec{
+			Name: &ast.Ident{Name: name},
+			Type: &ast.TypeSpec_Scalar{Scalar: &ast.ScalarType{Name: &ast.Ident{Name: name}}},
+		}},
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	parent := NewRegistry(nil)
+	parent.RegisterTypes(scalarDecl("A"), scalarDecl("B"))
+	parent.RegisterRepeatable("a")
+
+	b := scalarDecl("B")
+	child := NewRegistry(parent)
+	child.RegisterTypes(b, scalarDecl("C"))
+	child.RegisterRepeatable("c")
+
+	t.Run("Precedence", func(subT *testing.T) {
+		types := toDeclMap(child.Types())
+		if len(types) != 3 {
+			subT.Errorf("expected types: A, B and C but got: %v", TypeNames(types))
+		}
+		if l := types["B"]; len(l) != 1 || l[0] != b {
+			subT.Errorf("expected child declaration of B but got: %v", l)
+		}
+	})
+
+	t.Run("Replace", func(subT *testing.T) {
+		r := NewRegistry(nil)
+		r.RegisterTypes(scalarDecl("A"), scalarDecl("A"))
+		if len(r.Types()) != 1 {
+			subT.Errorf("expected only one declaration of A but got: %v", r.Types())
+		}
+	})
+
+	t.Run("Repeatable", func(subT *testing.T) {
+		if !child.IsRepeatable("a") || !child.IsRepeatable("c") {
+			subT.Error("expected repeatable directives from child and parent")
+		}
+		if parent.IsRepeatable("c") {
+			subT.Error("expected parent to not see child directives")
+		}
+		if parent.IsRepeatable("import") {
+			subT.Error("expected isolated registry to not see global directives")
+		}
+		if !NewRegistry(GlobalRegistry()).IsRepeatable("import") {
+			subT.Error("expected global directives to be inherited")
+		}
+	})
+
+	t.Run("CheckTypes", func(subT *testing.T) {
+		var seen []string
+		checker := TypeCheckerFn(func(ir IR) []error {
+			seen = TypeNames(ir[builtins])
+			return nil
+		})
+
+		ctx := WithRegistry(context.Background(), child)
+		if _, err := CheckTypesContext(ctx, make(IR), 0, checker); err != nil {
+			subT.Fatal(err)
+		}
+
+		if len(seen) != 3 {
+			subT.Errorf("expected registry types but got: %v", seen)
+		}
+	})
+}
+
+func TestRegistryConcurrentRepeatable(t *testing.T) {
+	r := NewRegistry(GlobalRegistry())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("d%d", i)
+			r.RegisterRepeatable(name)
+			r.IsRepeatable("import")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := 0; i < 4; i++ {
+		if name := fmt.Sprintf("d%d", i); !r.IsRepeatable(name) {
+			t.Errorf("expected directive to be repeatable: %s", name)
+		}
+	}
+}
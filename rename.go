@@ -0,0 +1,297 @@
+package compiler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// RenameTypes renames types and every reference to them (fields, args,
+// union members, interfaces, etc.), given a mapping of old names to new
+// names. Directive definitions can be renamed as well, in which case every
+// application of the directive is renamed too.
+//
+// Fields, input fields and enum values are renamed by mapping their path,
+// e.g. User.first_name, to their new name. Default values and directive
+// arguments which refer to a renamed enum value aren't rewritten.
+//
+// Renaming a type, directive or member onto a name which is already
+// declared, or renaming several of them onto the same name, is an error,
+// in which case the IR is left untouched.
+//
+func RenameTypes(ir IR, mapping map[string]string) (IR, error) {
+	if len(mapping) == 0 {
+		return ir, nil
+	}
+
+	members := make(map[string]map[string]string)
+	for path, n := range mapping {
+		i := strings.IndexByte(path, '.')
+		if i < 0 {
+			continue
+		}
+
+		typ, member := path[:i], path[i+1:]
+		if members[typ] == nil {
+			members[typ] = make(map[string]string)
+		}
+		members[typ][member] = n
+	}
+
+	// Directives and types live in separate namespaces
+	dirs := make(map[string]bool)
+	types := make(map[string]bool)
+	for _, mdecls := range ir {
+		for name, decls := range mdecls {
+			if DeclTok(decls[0]) == token.Token_DIRECTIVE {
+				dirs[name] = true
+				continue
+			}
+
+			types[name] = true
+		}
+	}
+	// Builtin and registered types are declared, even if they aren't in the IR
+	declared := map[string]bool{"ID": true, "Boolean": true, "String": true, "Int": true, "Float": true}
+	declaredDirs := make(map[string]bool, len(dirs))
+	for name, decls := range toDeclMap(Types) {
+		if DeclTok(decls[0]) == token.Token_DIRECTIVE {
+			declaredDirs[name] = true
+			continue
+		}
+		declared[name] = true
+	}
+	for name := range types {
+		declared[name] = true
+	}
+	for name := range dirs {
+		declaredDirs[name] = true
+	}
+
+	if err := checkRenames("", declaredDirs, mapping); err != nil {
+		return nil, err
+	}
+	if err := checkRenames("", declared, mapping); err != nil {
+		return nil, err
+	}
+	typs := make([]string, 0, len(members))
+	for typ := range members {
+		typs = append(typs, typ)
+	}
+	sort.Strings(typs)
+	for _, typ := range typs {
+		names := make(map[string]bool)
+		for _, mdecls := range ir {
+			for _, decl := range mdecls[typ] {
+				eachMember(TypeSpecOf(decl), func(id *ast.Ident) { names[id.Name] = true })
+			}
+		}
+
+		if err := checkRenames(typ+".", names, members[typ]); err != nil {
+			return nil, err
+		}
+	}
+
+	renameDir := func(_ string, d *ast.DirectiveLit) {
+		n, ok := mapping[d.Name]
+		if !ok || !dirs[d.Name] && types[d.Name] {
+			return
+		}
+
+		d.Name = n
+	}
+	renameRef := func(_ string, id *ast.Ident) {
+		if n, ok := mapping[id.Name]; ok {
+			id.Name = n
+		}
+	}
+
+	for doc, mdecls := range ir {
+		for _, d := range doc.Directives {
+			renameDir("", d)
+		}
+
+		renamed := make(map[string][]*ast.TypeDecl, len(mdecls))
+		for name, decls := range mdecls {
+			for _, decl := range decls {
+				ts := TypeSpecOf(decl)
+				if m, ok := members[name]; ok {
+					renameMembers(ts, m)
+				}
+
+				if ts.Name != nil {
+					renameRef("", ts.Name)
+				}
+				if s, ok := ts.Type.(*ast.TypeSpec_Scalar); ok && s.Scalar.Name != nil {
+					renameRef("", s.Scalar.Name)
+				}
+
+				walkRefs(ts, renameRef)
+				walkDirectives(ts, renameDir)
+			}
+
+			if n, ok := mapping[name]; ok {
+				name = n
+			}
+			renamed[name] = append(renamed[name], decls...)
+		}
+
+		ir[doc] = renamed
+	}
+
+	return ir, nil
+}
+
+// checkRenames returns an error if the mapping renames any of the given
+// names onto a name which is already declared, and not renamed itself,
+// or renames several of them onto the same name.
+//
+func checkRenames(prefix string, names map[string]bool, mapping map[string]string) error {
+	l := make([]string, 0, len(names))
+	for name := range names {
+		l = append(l, name)
+	}
+	sort.Strings(l)
+
+	// taken maps each name to the name renamed onto it, if any
+	taken := make(map[string]string, len(l))
+	for _, name := range l {
+		if n, ok := mapping[name]; !ok || n == name {
+			taken[name] = ""
+		}
+	}
+
+	for _, name := range l {
+		target, ok := mapping[name]
+		if !ok || target == name {
+			continue
+		}
+
+		src, dup := taken[target]
+		switch {
+		case dup && src == "":
+			return fmt.Errorf("compiler: cannot rename %s%s to %s: %s is already declared", prefix, name, target, target)
+		case dup:
+			return fmt.Errorf("compiler: cannot rename both %s%s and %s%s to %s", prefix, src, prefix, name, target)
+		}
+		taken[target] = name
+	}
+	return nil
+}
+
+// renameMembers renames the fields, input fields or enum values of a type.
+func renameMembers(ts *ast.TypeSpec, mapping map[string]string) {
+	eachMember(ts, func(id *ast.Ident) {
+		if n, ok := mapping[id.Name]; ok {
+			id.Name = n
+		}
+	})
+}
+
+// eachMember calls f with the name of each field, input field or enum
+// value of a type.
+//
+func eachMember(ts *ast.TypeSpec, f func(*ast.Ident)) {
+	var fields *ast.FieldList
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Object:
+		fields = v.Object.Fields
+	case *ast.TypeSpec_Interface:
+		fields = v.Interface.Fields
+	case *ast.TypeSpec_Enum:
+		fields = v.Enum.Values
+	case *ast.TypeSpec_Input:
+		if v.Input.Fields == nil {
+			return
+		}
+
+		for _, field := range v.Input.Fields.List {
+			f(field.Name)
+		}
+	}
+	if fields == nil {
+		return
+	}
+
+	for _, field := range fields.List {
+		f(field.Name)
+	}
+}
+
+// ReadRenames reads a mapping of old names to new names, for RenameTypes.
+// Each line of a renames file contains an old name, or path, and its new
+// name, e.g.:
+//
+// 	# Comments and blank lines are ignored
+// 	users User
+// 	User.first_name firstName
+//
+func ReadRenames(r io.Reader) (map[string]string, error) {
+	mapping := make(map[string]string)
+
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSpace(s.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		parts := strings.Fields(text)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("compiler: malformed rename on line %d: %s", line, text)
+		}
+
+		mapping[parts[0]] = parts[1]
+	}
+
+	return mapping, s.Err()
+}
+
+// WriteRenames writes a mapping of old names to new names, sorted by old
+// name, in the form read by ReadRenames.
+//
+func WriteRenames(w io.Writer, mapping map[string]string) error {
+	olds := make([]string, 0, len(mapping))
+	for old := range mapping {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+
+	bw := bufio.NewWriter(w)
+	for _, old := range olds {
+		fmt.Fprintf(bw, "%s %s\n", old, mapping[old])
+	}
+	return bw.Flush()
+}
+
+// PrefixTypes prefixes the name of every type declared in the IR,
+// along with every reference to them. Builtin types, registered types,
+// directives and the schema are left untouched. It returns an error if a
+// prefixed name is already declared, see RenameTypes.
+//
+func PrefixTypes(ir IR, prefix string) (IR, error) {
+	registered := toDeclMap(Types)
+
+	mapping := make(map[string]string)
+	for _, mdecls := range ir {
+		for name, decls := range mdecls {
+			switch DeclTok(decls[0]) {
+			case token.Token_SCHEMA, token.Token_DIRECTIVE:
+				continue
+			}
+
+			if _, ok := registered[name]; ok || isBuiltin(name) {
+				continue
+			}
+
+			mapping[name] = prefix + name
+		}
+	}
+
+	return RenameTypes(ir, mapping)
+}
@@ -0,0 +1,231 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+var renameGQL = `schema {
+	query: Query
+}
+
+directive @auth(role: Role) on FIELD_DEFINITION
+
+enum Role {
+	ADMIN
+}
+
+interface Node {
+	id: ID!
+}
+
+type User implements Node {
+	id: ID!
+	friends(first: Int, filter: Filter): [User!]! @auth(role: ADMIN)
+}
+
+union Result = User
+
+input Filter {
+	role: Role
+}
+
+type Query {
+	search: Result
+}`
+
+func TestRenameTypes(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(renameGQL), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ir, err := RenameTypes(ToIR([]*ast.Document{doc}), map[string]string{
+		"User": "Account",
+		"Role": "Permission",
+		"auth": "authz",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types := ir[doc]
+	for _, name := range []string{"User", "Role", "auth"} {
+		if _, ok := types[name]; ok {
+			t.Errorf("expected type to be renamed: %s", name)
+		}
+	}
+
+	refs := make(map[string]int)
+	dirs := make(map[string]int)
+	for _, decls := range types {
+		ts := TypeSpecOf(decls[0])
+		walkRefs(ts, func(_ string, id *ast.Ident) { refs[id.Name]++ })
+		walkDirectives(ts, func(_ string, d *ast.DirectiveLit) { dirs[d.Name]++ })
+	}
+
+	if refs["User"] > 0 || refs["Role"] > 0 || dirs["auth"] > 0 {
+		t.Errorf("found stale references: %v %v", refs, dirs)
+	}
+	if refs["Account"] != 2 || refs["Permission"] != 2 || dirs["authz"] != 1 {
+		t.Errorf("missing renamed references: %v %v", refs, dirs)
+	}
+}
+
+func TestPrefixTypes(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(renameGQL), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ir, err := PrefixTypes(ToIR([]*ast.Document{doc}), "Ext")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"schema", "auth", "ExtRole", "ExtNode", "ExtUser", "ExtResult", "ExtFilter", "ExtQuery"}
+	if len(ir[doc]) != len(expected) {
+		t.Errorf("expected %d types but got: %d", len(expected), len(ir[doc]))
+		return
+	}
+
+	for _, name := range expected {
+		if _, ok := ir[doc][name]; !ok {
+			t.Errorf("missing type: %s", name)
+		}
+	}
+}
+
+func TestRenameMembers(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(renameGQL+`
+
+extend type User {
+	full_name: String
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mapping, err := ReadRenames(strings.NewReader(`# Generated by lint
+User User
+User.full_name fullName
+Role.ADMIN ADMINISTRATOR
+Filter.role permission
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := WriteRenames(&b, mapping); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Filter.role permission\nRole.ADMIN ADMINISTRATOR\nUser User\nUser.full_name fullName\n"
+	if b.String() != expected {
+		t.Errorf("expected renames file:\n%s\nbut got:\n%s", expected, b.String())
+	}
+
+	ir, err := RenameTypes(ToIR([]*ast.Document{doc}), mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	fieldNames := func(fields *ast.FieldList) (l []string) {
+		for _, f := range fields.List {
+			l = append(l, f.Name.Name)
+		}
+		return
+	}
+	for _, name := range []string{"User", "Role", "Filter"} {
+		for _, decl := range ir[doc][name] {
+			switch v := TypeSpecOf(decl).Type.(type) {
+			case *ast.TypeSpec_Object:
+				names = append(names, fieldNames(v.Object.Fields)...)
+			case *ast.TypeSpec_Enum:
+				names = append(names, fieldNames(v.Enum.Values)...)
+			case *ast.TypeSpec_Input:
+				for _, f := range v.Input.Fields.List {
+					names = append(names, f.Name.Name)
+				}
+			}
+		}
+	}
+
+	if s := strings.Join(names, ","); s != "id,friends,fullName,ADMINISTRATOR,permission" {
+		t.Errorf("unexpected member names: %s", s)
+	}
+
+	if _, err := ReadRenames(strings.NewReader("a b c")); err == nil {
+		t.Error("expected error for malformed rename")
+	}
+}
+
+func TestRenameConflicts(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Mapping map[string]string
+		Err     string
+	}{
+		{
+			Name:    "Existing",
+			Mapping: map[string]string{"User": "Node"},
+			Err:     "compiler: cannot rename User to Node: Node is already declared",
+		},
+		{
+			Name:    "Builtin",
+			Mapping: map[string]string{"Role": "String"},
+			Err:     "compiler: cannot rename Role to String: String is already declared",
+		},
+		{
+			Name:    "SameTarget",
+			Mapping: map[string]string{"User": "Account", "Node": "Account"},
+			Err:     "compiler: cannot rename both Node and User to Account",
+		},
+		{
+			Name:    "Member",
+			Mapping: map[string]string{"User.friends": "id"},
+			Err:     "compiler: cannot rename User.friends to id: id is already declared",
+		},
+		{
+			Name:    "Directive",
+			Mapping: map[string]string{"auth": "import"},
+			Err:     "compiler: cannot rename auth to import: import is already declared",
+		},
+		{
+			Name:    "Swap",
+			Mapping: map[string]string{"User": "Node", "Node": "User"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(renameGQL), 0)
+			if err != nil {
+				subT.Fatal(err)
+			}
+			ir := ToIR([]*ast.Document{doc})
+
+			_, err = RenameTypes(ir, testCase.Mapping)
+			if testCase.Err == "" {
+				if err != nil {
+					subT.Errorf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil || err.Error() != testCase.Err {
+				subT.Errorf("expected error: %s, but got: %v", testCase.Err, err)
+			}
+			if _, ok := ir[doc]["User"]; !ok {
+				subT.Error("expected IR to be left untouched")
+			}
+		})
+	}
+}
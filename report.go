@@ -0,0 +1,43 @@
+package compiler
+
+import (
+	"context"
+	"time"
+)
+
+// Reporter is notified of the progress of each stage of a build, e.g.
+// to display progress or to profile slow builds of large schemas.
+//
+type Reporter interface {
+	// Start is called when a stage begins.
+	Start(stage string)
+
+	// Done is called when a stage ends, with how long it took.
+	Done(stage string, elapsed time.Duration)
+}
+
+type reporterKey struct{}
+
+// WithReporter returns a copy of ctx which carries the Reporter. The
+// context aware functions, e.g. CheckTypesContext, report their stages
+// to it.
+//
+func WithReporter(ctx context.Context, r Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, r)
+}
+
+// Stage reports the start of a stage to the Reporter carried by ctx, if
+// any, and returns a function which reports its end.
+//
+//	defer compiler.Stage(ctx, "generate:doc")()
+//
+func Stage(ctx context.Context, stage string) (done func()) {
+	r, ok := ctx.Value(reporterKey{}).(Reporter)
+	if !ok {
+		return func() {}
+	}
+
+	start := time.Now()
+	r.Start(stage)
+	return func() { r.Done(stage, time.Since(start)) }
+}
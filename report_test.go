@@ -0,0 +1,51 @@
+package compiler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testReporter struct {
+	events []string
+}
+
+func (r *testReporter) Start(stage string) { r.events = append(r.events, "start "+stage) }
+
+func (r *testReporter) Done(stage string, _ time.Duration) {
+	r.events = append(r.events, "done "+stage)
+}
+
+type namedChecker struct {
+	TypeCheckerFn
+}
+
+func (namedChecker) String() string { return "named" }
+
+func TestReporter(t *testing.T) {
+	r := new(testReporter)
+	ctx := WithReporter(context.Background(), r)
+
+	noop := TypeCheckerFn(func(IR) []error { return nil })
+	_, err := CheckTypesContext(ctx, make(IR), 0, noop, namedChecker{noop})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []string{
+		"start check",
+		"start check:0",
+		"done check:0",
+		"start check:named",
+		"done check:named",
+		"done check",
+	}
+	if !reflect.DeepEqual(r.events, expected) {
+		t.Errorf("expected: %v but got: %v", expected, r.events)
+	}
+
+	t.Run("NoReporter", func(subT *testing.T) {
+		Stage(context.Background(), "noop")()
+	})
+}
@@ -0,0 +1,239 @@
+package compiler
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+// ParseTypes parses the type declarations of an SDL string, e.g. for
+// registering custom scalars and directives with the compiler.
+//
+func ParseTypes(sdl string) ([]*ast.TypeDecl, error) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "sdl", strings.NewReader(sdl), 0)
+	if err != nil {
+		return nil, err
+	}
+	return doc.Types, nil
+}
+
+// RegisterTypesFromSDL parses the types declared in an SDL string and
+// registers them with the compiler, as RegisterTypes does:
+//
+//	err := compiler.RegisterTypesFromSDL(`
+//	scalar Time
+//
+//	directive @cache(maxAge: Int!) on FIELD_DEFINITION
+//	`)
+//
+func RegisterTypesFromSDL(sdl string) error {
+	return global.RegisterTypesFromSDL(sdl)
+}
+
+// RegisterTypesFromSDL parses the types declared in an SDL string and
+// registers them with the Registry.
+//
+func (r *Registry) RegisterTypesFromSDL(sdl string) error {
+	decls, err := ParseTypes(sdl)
+	if err != nil {
+		return err
+	}
+
+	r.RegisterTypes(decls...)
+	return nil
+}
+
+// WriteSDL writes a Document as GraphQL SDL: its description and directives,
+// followed by its type declarations, in order, separated by blank lines.
+// Descriptions are kept, but comments are not.
+//
+func WriteSDL(w io.Writer, doc *ast.Document) error {
+	p := &sdlPrinter{w: bufio.NewWriter(w)}
+
+	if s := Description(doc.Doc); s != "" {
+		p.description("", s)
+		p.WriteString("\n")
+	}
+	for _, d := range doc.Directives {
+		p.WriteString(DirectiveString(d))
+		p.WriteString("\n")
+	}
+
+	for i, decl := range doc.Types {
+		if i > 0 || len(doc.Directives) > 0 {
+			p.WriteString("\n")
+		}
+		p.decl(decl)
+	}
+	return p.w.Flush()
+}
+
+type sdlPrinter struct {
+	w *bufio.Writer
+}
+
+func (p *sdlPrinter) WriteString(s string) { p.w.WriteString(s) }
+
+func (p *sdlPrinter) description(indent, s string) {
+	if s == "" {
+		return
+	}
+
+	p.WriteString(indent)
+	if !strings.Contains(s, "\n") && !strings.Contains(s, `"`) {
+		p.WriteString(`"` + strings.Replace(s, `\`, `\\`, -1) + `"` + "\n")
+		return
+	}
+
+	p.WriteString(`"""` + "\n")
+	for _, l := range strings.Split(strings.Replace(s, `"""`, `\"""`, -1), "\n") {
+		if l != "" {
+			p.WriteString(indent + l)
+		}
+		p.WriteString("\n")
+	}
+	p.WriteString(indent + `"""` + "\n")
+}
+
+func (p *sdlPrinter) directives(dirs []*ast.DirectiveLit) {
+	for _, d := range dirs {
+		p.WriteString(" " + DirectiveString(d))
+	}
+}
+
+func (p *sdlPrinter) decl(decl *ast.TypeDecl) {
+	ts := TypeSpecOf(decl)
+	if ts == nil {
+		return
+	}
+
+	p.description("", Description(decl.Doc))
+	if _, ok := decl.Spec.(*ast.TypeDecl_TypeExtSpec); ok {
+		p.WriteString("extend ")
+	}
+
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		p.WriteString("schema")
+		p.directives(ts.Directives)
+		p.fields(v.Schema.RootOps)
+	case *ast.TypeSpec_Scalar:
+		p.WriteString("scalar " + ts.Name.Name)
+		p.directives(ts.Directives)
+	case *ast.TypeSpec_Object:
+		p.WriteString("type " + ts.Name.Name)
+		if len(v.Object.Interfaces) > 0 {
+			names := make([]string, 0, len(v.Object.Interfaces))
+			for _, i := range v.Object.Interfaces {
+				names = append(names, i.Name)
+			}
+			p.WriteString(" implements " + strings.Join(names, " & "))
+		}
+		p.directives(ts.Directives)
+		p.fields(v.Object.Fields)
+	case *ast.TypeSpec_Interface:
+		p.WriteString("interface " + ts.Name.Name)
+		p.directives(ts.Directives)
+		p.fields(v.Interface.Fields)
+	case *ast.TypeSpec_Union:
+		p.WriteString("union " + ts.Name.Name)
+		p.directives(ts.Directives)
+		if len(v.Union.Members) > 0 {
+			names := make([]string, 0, len(v.Union.Members))
+			for _, m := range v.Union.Members {
+				names = append(names, m.Name)
+			}
+			p.WriteString(" = " + strings.Join(names, " | "))
+		}
+	case *ast.TypeSpec_Enum:
+		p.WriteString("enum " + ts.Name.Name)
+		p.directives(ts.Directives)
+		p.fields(v.Enum.Values)
+	case *ast.TypeSpec_Input:
+		p.WriteString("input " + ts.Name.Name)
+		p.directives(ts.Directives)
+		if v.Input.Fields != nil && len(v.Input.Fields.List) > 0 {
+			p.WriteString(" {\n")
+			for _, f := range v.Input.Fields.List {
+				p.description("\t", Description(f.Doc))
+				p.WriteString("\t")
+				p.inputValue(f)
+				p.WriteString("\n")
+			}
+			p.WriteString("}")
+		}
+	case *ast.TypeSpec_Directive:
+		p.WriteString("directive @" + ts.Name.Name)
+		p.args("", v.Directive.Args)
+
+		locs := make([]string, 0, len(v.Directive.Locs))
+		for _, l := range v.Directive.Locs {
+			locs = append(locs, l.Loc.String())
+		}
+		p.WriteString(" on " + strings.Join(locs, " | "))
+	}
+	p.WriteString("\n")
+}
+
+// fields writes a block of fields, root operations or enum values.
+func (p *sdlPrinter) fields(fields *ast.FieldList) {
+	if fields == nil || len(fields.List) == 0 {
+		return
+	}
+
+	p.WriteString(" {\n")
+	for _, f := range fields.List {
+		p.description("\t", Description(f.Doc))
+		p.WriteString("\t" + f.Name.Name)
+		p.args("\t", f.Args)
+		if t := TypeString(fieldType(f)); t != "" {
+			p.WriteString(": " + t)
+		}
+		p.directives(f.Directives)
+		p.WriteString("\n")
+	}
+	p.WriteString("}")
+}
+
+// args writes an argument list, on a single line unless any of the
+// arguments have a description.
+//
+func (p *sdlPrinter) args(indent string, args *ast.InputValueList) {
+	if args == nil || len(args.List) == 0 {
+		return
+	}
+
+	multiline := false
+	for _, a := range args.List {
+		multiline = multiline || Description(a.Doc) != ""
+	}
+
+	p.WriteString("(")
+	for i, a := range args.List {
+		switch {
+		case multiline:
+			p.WriteString("\n")
+			p.description(indent+"\t", Description(a.Doc))
+			p.WriteString(indent + "\t")
+		case i > 0:
+			p.WriteString(", ")
+		}
+		p.inputValue(a)
+	}
+	if multiline {
+		p.WriteString("\n" + indent)
+	}
+	p.WriteString(")")
+}
+
+func (p *sdlPrinter) inputValue(v *ast.InputValue) {
+	p.WriteString(v.Name.Name + ": " + TypeString(inputType(v)))
+	if d := DefaultString(v); d != "" {
+		p.WriteString(" = " + d)
+	}
+	p.directives(v.Directives)
+}
@@ -0,0 +1,109 @@
+package compiler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestRegisterTypesFromSDL(t *testing.T) {
+	r := NewRegistry(nil)
+
+	err := r.RegisterTypesFromSDL(`scalar Time
+
+directive @cache(maxAge: Int!) on FIELD_DEFINITION`)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	types := TypeNames(toDeclMap(r.Types()))
+	if len(types) != 2 || types[0] != "Time" || types[1] != "cache" {
+		t.Errorf("expected types: Time and cache but got: %v", types)
+	}
+
+	t.Run("Malformed", func(subT *testing.T) {
+		if err := r.RegisterTypesFromSDL(`scalar`); err == nil {
+			subT.Error("expected parse error")
+		}
+	})
+}
+
+func TestWriteSDL(t *testing.T) {
+	src := `"About this API."
+
+"The schema."
+schema @a {
+	query: Query
+}
+
+"A date."
+scalar Date @a
+
+"""
+The root query.
+
+Spans lines.
+"""
+type Query implements Node & Named @a {
+	"The id."
+	id: ID!
+	name: String @deprecated(reason: "use id")
+	search(
+		"The text."
+		text: String! = "x"
+		first: Int = 10
+	): [Result!]!
+	recent(first: Int = 10, roles: [Role!] = [ADMIN]): [Result]
+}
+
+interface Node {
+	id: ID!
+}
+
+union Result @a = Query | Node
+
+enum Role {
+	"Admins."
+	ADMIN
+	USER @deprecated
+}
+
+input Filter @a {
+	"The text."
+	text: String = "x" @a
+	role: Role
+}
+
+directive @a(b: Int) on SCHEMA | SCALAR | OBJECT | UNION | INPUT_OBJECT | INPUT_FIELD_DEFINITION
+
+extend type Query {
+	date: Date
+}`
+
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(src), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := ToIR([]*ast.Document{doc})
+
+	var b bytes.Buffer
+	if err := WriteSDL(&b, doc); err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != src+"\n" {
+		t.Errorf("expected SDL:\n%s\nbut got:\n%s", src, b.String())
+	}
+
+	out, err := parser.ParseDoc(token.NewDocSet(), "out", &b, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if SchemaHash(ToIR([]*ast.Document{out})) != SchemaHash(ir) {
+		t.Error("expected written SDL to declare the same schema")
+	}
+}
@@ -0,0 +1,170 @@
+package compiler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// SourceMapExt is the conventional extension of a source map, which is
+// written next to the generated file it maps, e.g. user.go.gqlmap.json
+//
+const SourceMapExt = ".gqlmap.json"
+
+// SourceMapping links a region of a generated file to the type declaration,
+// or field, it was generated from.
+type SourceMapping struct {
+	// Lines of the generated region, starting at 1, inclusive
+	Start int `json:"start"`
+	End   int `json:"end"`
+
+	// Name of the Document which declares the type
+	Doc string `json:"doc"`
+
+	// Name of the type and, optionally, the field
+	Type  string `json:"type"`
+	Field string `json:"field,omitempty"`
+
+	// Line of the declaration within the Document, or zero if unknown
+	Line int `json:"line,omitempty"`
+}
+
+// SourceMap links the regions of a generated file back to the type
+// declarations they were generated from, enabling "go to schema definition"
+// tooling and attributing runtime errors to the schema.
+//
+// A source map is stored as JSON, with its mappings sorted by region:
+// {"file": "...", "mappings": [{"start": 1, "end": 3, "doc": "...", "type": "...", "line": 1}]}
+//
+type SourceMap struct {
+	// File is the path of the generated file
+	File string `json:"file"`
+
+	Mappings []*SourceMapping `json:"mappings"`
+
+	docs *token.DocSet
+}
+
+// NewSourceMap returns an empty SourceMap for the generated file. The DocSet
+// the Documents were parsed with is used to resolve declaration lines; it may
+// be nil, in which case lines are left unknown.
+//
+func NewSourceMap(file string, docs *token.DocSet) *SourceMap {
+	return &SourceMap{File: file, docs: docs}
+}
+
+// ReadSourceMap reads a SourceMap.
+func ReadSourceMap(r io.Reader) (*SourceMap, error) {
+	m := new(SourceMap)
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, fmt.Errorf("compiler: malformed source map: %s", err)
+	}
+	return m, nil
+}
+
+// WriteTo writes the SourceMap to w, sorted by region.
+func (m *SourceMap) WriteTo(w io.Writer) (int64, error) {
+	sort.SliceStable(m.Mappings, func(i, j int) bool {
+		a, b := m.Mappings[i], m.Mappings[j]
+		if a.Start != b.Start {
+			return a.Start < b.Start
+		}
+		return a.End > b.End
+	})
+
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := w.Write(append(b, '\n'))
+	return int64(n), err
+}
+
+// Add records that the lines start through end, inclusive, of the generated
+// file were generated from the declaration of a type in doc. The field is
+// empty when the region maps to the whole type.
+//
+func (m *SourceMap) Add(start, end int, doc *ast.Document, decl *ast.TypeDecl, field string) {
+	mapping := &SourceMapping{Start: start, End: end, Doc: doc.Name, Field: field}
+
+	pos := decl.TokPos
+	if ts := TypeSpecOf(decl); ts != nil {
+		mapping.Type = "schema"
+		if ts.Name != nil {
+			mapping.Type = ts.Name.Name
+		}
+
+		if f := lookupMember(ts, field); f != nil {
+			pos = f.NamePos
+		}
+	}
+
+	if m.docs != nil && pos > 0 {
+		mapping.Line = m.docs.Position(token.Pos(pos)).Line
+	}
+	m.Mappings = append(m.Mappings, mapping)
+}
+
+// Lookup returns the innermost mapping which contains the given line of the
+// generated file, or nil if the line wasn't generated from the schema.
+//
+func (m *SourceMap) Lookup(line int) *SourceMapping {
+	var found *SourceMapping
+	for _, mapping := range m.Mappings {
+		if line < mapping.Start || line > mapping.End {
+			continue
+		}
+
+		if found == nil || mapping.End-mapping.Start < found.End-found.Start {
+			found = mapping
+		}
+	}
+	return found
+}
+
+// lookupMember returns the name of the named field, argument, input field or
+// enum value of a TypeSpec, or nil if there isn't one.
+//
+func lookupMember(ts *ast.TypeSpec, name string) *ast.Ident {
+	if name == "" {
+		return nil
+	}
+
+	var fields *ast.FieldList
+	var args *ast.InputValueList
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		fields = v.Schema.RootOps
+	case *ast.TypeSpec_Object:
+		fields = v.Object.Fields
+	case *ast.TypeSpec_Interface:
+		fields = v.Interface.Fields
+	case *ast.TypeSpec_Enum:
+		fields = v.Enum.Values
+	case *ast.TypeSpec_Input:
+		args = v.Input.Fields
+	case *ast.TypeSpec_Directive:
+		args = v.Directive.Args
+	}
+
+	if fields != nil {
+		for _, f := range fields.List {
+			if f.Name.Name == name {
+				return f.Name
+			}
+		}
+	}
+	if args != nil {
+		for _, a := range args.List {
+			if a.Name.Name == name {
+				return a.Name
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package compiler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestSourceMap(t *testing.T) {
+	dset := token.NewDocSet()
+	doc, err := parser.ParseDoc(dset, "user.gql", strings.NewReader(`scalar Time
+
+type User {
+	id: ID!
+
+	joined: Time
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ir := ToIR([]*ast.Document{doc})
+
+	m := NewSourceMap("models/user.go", dset)
+	m.Add(5, 6, doc, ir[doc]["User"][0], "joined")
+	m.Add(1, 8, doc, ir[doc]["User"][0], "")
+	m.Add(10, 10, doc, ir[doc]["Time"][0], "")
+
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err = ReadSourceMap(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.File != "models/user.go" || m.Mappings[0].Start != 1 {
+		t.Errorf("expected mappings to be sorted by region but got: %v", m.Mappings)
+	}
+
+	testCases := []struct {
+		Name  string
+		Line  int
+		Type  string
+		Field string
+		Src   int
+	}{
+		{Name: "Type", Line: 2, Type: "User", Src: 3},
+		{Name: "Field", Line: 6, Type: "User", Field: "joined", Src: 6},
+		{Name: "Scalar", Line: 10, Type: "Time", Src: 1},
+		{Name: "Unmapped", Line: 9},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			mapping := m.Lookup(testCase.Line)
+			if testCase.Type == "" {
+				if mapping != nil {
+					subT.Errorf("expected line %d to be unmapped but got: %v", testCase.Line, mapping)
+				}
+				return
+			}
+
+			if mapping == nil {
+				subT.Fatalf("expected line %d to be mapped", testCase.Line)
+			}
+			if mapping.Doc != "user.gql" || mapping.Type != testCase.Type || mapping.Field != testCase.Field || mapping.Line != testCase.Src {
+				subT.Errorf("unexpected mapping: %v", mapping)
+			}
+		})
+	}
+
+	if _, err = ReadSourceMap(strings.NewReader("{")); err == nil {
+		t.Error("expected error for malformed source map")
+	}
+}
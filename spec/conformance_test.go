@@ -0,0 +1,73 @@
+package spec
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+// TestConformance validates the corpus of SDL documents in testdata/conformance,
+// which is derived from the examples of the GraphQL spec. Documents in valid/ must
+// be accepted. Documents in invalid/ must be rejected with one error for each of
+// their "# expect: <message>" comments, in order.
+//
+func TestConformance(t *testing.T) {
+	reg := compiler.NewRegistry(nil)
+	reg.RegisterTypes(BuiltinTypes...)
+	ctx := compiler.WithRegistry(context.Background(), reg)
+
+	for _, dir := range []string{"valid", "invalid"} {
+		files, err := filepath.Glob(filepath.Join("testdata", "conformance", dir, "*.graphql"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, file := range files {
+			t.Run(dir+"/"+strings.TrimSuffix(filepath.Base(file), ".graphql"), func(subT *testing.T) {
+				src, err := ioutil.ReadFile(file)
+				if err != nil {
+					subT.Fatal(err)
+				}
+
+				var expected []string
+				s := bufio.NewScanner(bytes.NewReader(src))
+				for s.Scan() {
+					if l := s.Text(); strings.HasPrefix(l, "# expect: ") {
+						expected = append(expected, strings.TrimPrefix(l, "# expect: "))
+					}
+				}
+				if dir == "invalid" && len(expected) == 0 {
+					subT.Fatal("invalid documents must declare their expected errors")
+				}
+
+				doc, err := parser.ParseDoc(token.NewDocSet(), file, bytes.NewReader(src), 0)
+				if err != nil {
+					subT.Fatal(err)
+				}
+
+				errs, err := compiler.CheckTypesContext(ctx, compiler.ToIR([]*ast.Document{doc}), 0, NewValidator(0))
+				if err != nil {
+					subT.Fatal(err)
+				}
+
+				if len(errs) != len(expected) {
+					subT.Fatalf("expected errors: %v but got: %v", expected, errs)
+				}
+				for i, err := range errs {
+					if !strings.Contains(err.Error(), expected[i]) {
+						subT.Errorf("expected error: %s but got: %s", expected[i], err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gqlc/graphql/ast"
+)
+
+// Edition represents a release of the GraphQL spec. Validators can be pinned
+// to an Edition, see NewEditionValidator, so that schemas only use the
+// features supported by the GraphQL runtime they're served by.
+//
+type Edition int
+
+// The editions of the GraphQL spec, in release order.
+const (
+	// June2018 disallows repeatable directives, schema descriptions and @specifiedBy.
+	June2018 Edition = iota + 1
+
+	// October2021 adds repeatable directives, schema descriptions, @specifiedBy
+	// and interfaces implementing interfaces.
+	October2021
+
+	// Draft is the working draft of the spec, and is what Validator uses.
+	Draft
+)
+
+var editionNames = map[Edition]string{
+	June2018:    "June2018",
+	October2021: "October2021",
+	Draft:       "Draft",
+}
+
+// String returns the name of the edition, e.g. October2021.
+func (e Edition) String() string {
+	if s, ok := editionNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("Edition(%d)", int(e))
+}
+
+// ParseEdition returns the Edition with the given name, e.g. June2018.
+// Names are case insensitive.
+//
+func ParseEdition(s string) (Edition, error) {
+	for e, name := range editionNames {
+		if strings.EqualFold(name, s) {
+			return e, nil
+		}
+	}
+	return 0, fmt.Errorf("spec: unknown edition: %s", s)
+}
+
+// allows reports whether a feature introduced by the since edition can be
+// used. The zero Edition allows every feature.
+//
+func (e Edition) allows(since Edition) bool {
+	return e == 0 || e >= since
+}
+
+// editionDirectives contains the builtin directives which were introduced
+// after the first edition.
+//
+var editionDirectives = map[string]Edition{
+	"specifiedBy": October2021,
+}
+
+// validateEdition validates that a type declaration only uses features
+// supported by the edition being validated against.
+//
+func validateEdition(decl *ast.TypeDecl, loc ast.DirectiveLocation_Loc, items typeDecls, errs *[]error) {
+	if loc != ast.DirectiveLocation_SCHEMA || items.edition.allows(October2021) || decl.Doc == nil {
+		return
+	}
+
+	for _, d := range decl.Doc.List {
+		if !d.Comment {
+			*errs = append(*errs, fmt.Errorf("schema: descriptions are not supported by the %s edition", items.edition))
+			return
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package spec
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestEditions(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Src     string
+		Edition Edition
+		Errs    []string
+	}{
+		{
+			Name:    "SchemaDescription",
+			Src:     "\"The API\"\nschema { query: Query }\ntype Query { a: Int }",
+			Edition: June2018,
+			Errs:    []string{"schema: descriptions are not supported by the June2018 edition"},
+		},
+		{
+			Name:    "SchemaDescription2021",
+			Src:     "\"The API\"\nschema { query: Query }\ntype Query { a: Int }",
+			Edition: October2021,
+		},
+		{
+			Name:    "SchemaComment",
+			Src:     "# Not a description\nschema { query: Query }\ntype Query { a: Int }",
+			Edition: June2018,
+		},
+		{
+			Name:    "SpecifiedBy",
+			Src:     `scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")`,
+			Edition: June2018,
+			Errs:    []string{"specifiedBy: directive is not supported by the June2018 edition"},
+		},
+		{
+			Name:    "SpecifiedBy2021",
+			Src:     `scalar UUID @specifiedBy(url: "https://tools.ietf.org/html/rfc4122")`,
+			Edition: October2021,
+		},
+		{
+			Name:    "Repeatable",
+			Src:     "directive @tag(name: String) on OBJECT\ntype Query @tag(name: \"a\") @tag(name: \"b\") { a: Int }",
+			Edition: June2018,
+			Errs:    []string{"tag: directive cannot be applied more than once per location: OBJECT"},
+		},
+		{
+			Name:    "RepeatableDraft",
+			Src:     "directive @tag(name: String) on OBJECT\ntype Query @tag(name: \"a\") @tag(name: \"b\") { a: Int }",
+			Edition: Draft,
+		},
+		{
+			Name:    "RepeatableImport",
+			Src:     "@import(paths: [\"a\"])\n@import(paths: [\"b\"])\ntype Query { a: Int }",
+			Edition: June2018,
+		},
+	}
+
+	reg := compiler.NewRegistry(compiler.GlobalRegistry())
+	reg.RegisterRepeatable("tag")
+	ctx := compiler.WithRegistry(context.Background(), reg)
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(testCase.Src), parser.ParseComments)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			errs, err := compiler.CheckTypesContext(ctx, compiler.ToIR([]*ast.Document{doc}), 0, NewEditionValidator(testCase.Edition, 1))
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if len(errs) != len(testCase.Errs) {
+				subT.Fatalf("expected errors: %v but got: %v", testCase.Errs, errs)
+			}
+			for i, err := range errs {
+				if err.Error() != testCase.Errs[i] {
+					subT.Errorf("expected error: %s but got: %s", testCase.Errs[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseEdition(t *testing.T) {
+	for _, e := range []Edition{June2018, October2021, Draft} {
+		pe, err := ParseEdition(strings.ToLower(e.String()))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if pe != e {
+			t.Errorf("expected edition: %s but got: %s", e, pe)
+		}
+	}
+
+	if _, err := ParseEdition("2015"); err == nil {
+		t.Error("expected error for unknown edition")
+	}
+}
@@ -0,0 +1,341 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// FederationTypes contains the types and directives defined by the
+// Apollo Federation subgraph specification.
+//
+// scalar _Any
+// scalar _FieldSet
+// type _Service { sdl: String }
+// directive @key(fields: _FieldSet!) repeatable on OBJECT | INTERFACE
+// directive @external on FIELD_DEFINITION
+// directive @requires(fields: _FieldSet!) on FIELD_DEFINITION
+// directive @provides(fields: _FieldSet!) on FIELD_DEFINITION
+// directive @extends on OBJECT | INTERFACE
+//
+var FederationTypes = []*ast.TypeDecl{
+	compiler.NewScalar("_Any").Decl(),
+	compiler.NewScalar("_FieldSet").Decl(),
+	compiler.NewObject("_Service").Field("sdl", "String").Decl(),
+	federationDirective("key", true, ast.DirectiveLocation_OBJECT, ast.DirectiveLocation_INTERFACE),
+	federationDirective("external", false, ast.DirectiveLocation_FIELD_DEFINITION),
+	federationDirective("requires", true, ast.DirectiveLocation_FIELD_DEFINITION),
+	federationDirective("provides", true, ast.DirectiveLocation_FIELD_DEFINITION),
+	federationDirective("extends", false, ast.DirectiveLocation_OBJECT, ast.DirectiveLocation_INTERFACE),
+}
+
+func federationDirective(name string, fieldSet bool, locs ...ast.DirectiveLocation_Loc) *ast.TypeDecl {
+	b := compiler.NewDirective(name, locs...)
+	if fieldSet {
+		b.Field("fields", compiler.NonNull("_FieldSet"))
+	}
+	return b.Decl()
+}
+
+// RegisterFederation registers the FederationTypes with r, along with
+// @key as a repeatable directive. Federation support is opt-in, since not
+// every schema is a subgraph.
+//
+func RegisterFederation(r *compiler.Registry) {
+	r.RegisterTypes(FederationTypes...)
+	r.RegisterRepeatable("key")
+}
+
+// FederationValidator validates the usage of the federation directives:
+//
+// 	- @key fields must be fields of the entity
+// 	- @requires fields must be @external fields of the same type
+// 	- @provides fields must be fields of the returned type
+// 	- @external fields must belong to an entity, i.e. a type with @key or @extends
+//
+var FederationValidator = compiler.TypeCheckerFn(validateFederation)
+
+func validateFederation(ir compiler.IR) (errs []error) {
+	for _, doc := range ir.Documents() {
+		types := ir[doc]
+		for _, name := range compiler.TypeNames(types) {
+			fields := objectFields(types[name])
+			if fields == nil {
+				continue
+			}
+
+			entity := false
+			for _, decl := range types[name] {
+				for _, d := range compiler.TypeSpecOf(decl).Directives {
+					switch d.Name {
+					case "key":
+						entity = true
+
+						for _, f := range fieldSet(d) {
+							if _, ok := fields[f]; !ok {
+								errs = append(errs, fmt.Errorf("%s: @key field does not exist: %s", name, f))
+							}
+						}
+					case "extends":
+						entity = true
+					}
+				}
+			}
+
+			for _, decl := range types[name] {
+				for _, field := range objectFieldList(decl) {
+					validateFederatedField(name, field, fields, entity, ir, &errs)
+				}
+			}
+		}
+	}
+	return
+}
+
+func validateFederatedField(name string, field *ast.Field, fields map[string]*ast.Field, entity bool, ir compiler.IR, errs *[]error) {
+	fname := field.Name.Name
+	for _, d := range field.Directives {
+		switch d.Name {
+		case "external":
+			if !entity {
+				*errs = append(*errs, fmt.Errorf("%s:%s: @external field must belong to an entity", name, fname))
+			}
+		case "requires":
+			for _, f := range fieldSet(d) {
+				rf, ok := fields[f]
+				if !ok {
+					*errs = append(*errs, fmt.Errorf("%s:%s: @requires field does not exist: %s", name, fname, f))
+					continue
+				}
+
+				if !hasDirective(rf.Directives, "external") {
+					*errs = append(*errs, fmt.Errorf("%s:%s: @requires field must be marked @external: %s", name, fname, f))
+				}
+			}
+		case "provides":
+			var id *ast.Ident
+			switch v := field.Type.(type) {
+			case *ast.Field_Ident:
+				id = v.Ident
+			case *ast.Field_List:
+				id = unwrapType(v.List)
+			case *ast.Field_NonNull:
+				id = unwrapType(v.NonNull)
+			}
+			if id == nil {
+				continue
+			}
+
+			_, decls := compiler.Lookup(id.Name, ir)
+			pfields := objectFields(decls)
+			if pfields == nil {
+				*errs = append(*errs, fmt.Errorf("%s:%s: @provides can only be applied to fields which return an object type", name, fname))
+				continue
+			}
+
+			for _, f := range fieldSet(d) {
+				if _, ok := pfields[f]; !ok {
+					*errs = append(*errs, fmt.Errorf("%s:%s: @provides field does not exist on %s: %s", name, fname, id.Name, f))
+				}
+			}
+		}
+	}
+}
+
+// Federate augments the IR with the federation entry points: the _Entity
+// union of every entity type, and the _entities and _service fields on
+// the query type. If no query type exists, one is declared alongside the
+// first entity.
+//
+// union _Entity = ...
+//
+// extend type Query {
+// 	_entities(representations: [_Any!]!): [_Entity]!
+// 	_service: _Service!
+// }
+//
+func Federate(ir compiler.IR) compiler.IR {
+	var entities []*ast.Ident
+	var entityDoc *ast.Document
+	for _, doc := range ir.Documents() {
+		if compiler.IsBuiltins(doc) {
+			continue
+		}
+
+		types := ir[doc]
+		for _, name := range compiler.TypeNames(types) {
+			if objectFields(types[name]) == nil || !isEntity(types[name]) {
+				continue
+			}
+
+			if entityDoc == nil {
+				entityDoc = doc
+			}
+			entities = append(entities, &ast.Ident{Name: name})
+		}
+	}
+
+	query := "Query"
+	for name, op := range compiler.RootOperations(ir) {
+		if op == "query" {
+			query = name
+		}
+	}
+
+	doc, _ := compiler.Lookup(query, ir)
+	if doc == nil {
+		doc = entityDoc
+	}
+	if doc == nil {
+		return ir
+	}
+
+	fields := []*ast.Field{
+		{
+			Name: &ast.Ident{Name: "_service"},
+			Type: &ast.Field_NonNull{NonNull: &ast.NonNull{
+				Type: &ast.NonNull_Ident{Ident: &ast.Ident{Name: "_Service"}},
+			}},
+		},
+	}
+
+	if len(entities) > 0 {
+		ir[doc]["_Entity"] = []*ast.TypeDecl{
+			{
+				Tok: token.Token_UNION,
+				Spec: &ast.TypeDecl_TypeSpec{TypeSpec: &ast.TypeSpec{
+					Name: &ast.Ident{Name: "_Entity"},
+					Type: &ast.TypeSpec_Union{Union: &ast.UnionType{Members: entities}},
+				}},
+			},
+		}
+
+		fields = append([]*ast.Field{
+			{
+				Name: &ast.Ident{Name: "_entities"},
+				Args: &ast.InputValueList{List: []*ast.InputValue{
+					{
+						Name: &ast.Ident{Name: "representations"},
+						Type: &ast.InputValue_NonNull{NonNull: &ast.NonNull{
+							Type: &ast.NonNull_List{List: &ast.List{
+								Type: &ast.List_NonNull{NonNull: &ast.NonNull{
+									Type: &ast.NonNull_Ident{Ident: &ast.Ident{Name: "_Any"}},
+								}},
+							}},
+						}},
+					},
+				}},
+				Type: &ast.Field_NonNull{NonNull: &ast.NonNull{
+					Type: &ast.NonNull_List{List: &ast.List{
+						Type: &ast.List_Ident{Ident: &ast.Ident{Name: "_Entity"}},
+					}},
+				}},
+			},
+		}, fields...)
+	}
+
+	ts := &ast.TypeSpec{
+		Name: &ast.Ident{Name: query},
+		Type: &ast.TypeSpec_Object{Object: &ast.ObjectType{
+			Fields: &ast.FieldList{List: fields},
+		}},
+	}
+
+	if _, ok := ir[doc][query]; !ok {
+		ir[doc][query] = []*ast.TypeDecl{{Tok: token.Token_TYPE, Spec: &ast.TypeDecl_TypeSpec{TypeSpec: ts}}}
+		return ir
+	}
+
+	ir[doc][query] = append(ir[doc][query], &ast.TypeDecl{
+		Tok:  token.Token_EXTEND,
+		Spec: &ast.TypeDecl_TypeExtSpec{TypeExtSpec: &ast.TypeExtensionSpec{Tok: token.Token_TYPE, Type: ts}},
+	})
+	return ir
+}
+
+func isEntity(decls []*ast.TypeDecl) bool {
+	for _, decl := range decls {
+		if hasDirective(compiler.TypeSpecOf(decl).Directives, "key") {
+			return true
+		}
+	}
+	return false
+}
+
+func objectFieldList(decl *ast.TypeDecl) []*ast.Field {
+	obj, ok := compiler.TypeSpecOf(decl).Type.(*ast.TypeSpec_Object)
+	if !ok || obj.Object.Fields == nil {
+		return nil
+	}
+	return obj.Object.Fields.List
+}
+
+// objectFields returns the fields of an object type, and its extensions,
+// or nil if the declarations aren't an object type.
+//
+func objectFields(decls []*ast.TypeDecl) map[string]*ast.Field {
+	if len(decls) == 0 {
+		return nil
+	}
+	if _, ok := compiler.TypeSpecOf(decls[0]).Type.(*ast.TypeSpec_Object); !ok {
+		return nil
+	}
+
+	fields := make(map[string]*ast.Field)
+	for _, decl := range decls {
+		for _, f := range objectFieldList(decl) {
+			fields[f.Name.Name] = f
+		}
+	}
+	return fields
+}
+
+func hasDirective(dirs []*ast.DirectiveLit, name string) bool {
+	for _, d := range dirs {
+		if d.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldSet returns the top-level field names selected by a directive's
+// fields argument, e.g. "id organization { id }" selects id and organization.
+//
+func fieldSet(d *ast.DirectiveLit) (names []string) {
+	if d.Args == nil {
+		return
+	}
+
+	for _, arg := range d.Args.Args {
+		if arg.Name == nil || arg.Name.Name != "fields" {
+			continue
+		}
+
+		lit, ok := arg.Value.(*ast.Arg_BasicLit)
+		if !ok {
+			continue
+		}
+
+		sel := strings.Trim(lit.BasicLit.Value, "\"")
+		sel = strings.NewReplacer("{", " { ", "}", " } ", ",", " ").Replace(sel)
+
+		var depth int
+		for _, tok := range strings.Fields(sel) {
+			switch tok {
+			case "{":
+				depth++
+			case "}":
+				depth--
+			default:
+				if depth == 0 {
+					names = append(names, tok)
+				}
+			}
+		}
+	}
+	return
+}
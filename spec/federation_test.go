@@ -0,0 +1,164 @@
+package spec
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestFederationValidator(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Src  string
+		Errs []string
+	}{
+		{
+			Name: "Valid",
+			Src: `type Product @key(fields: "upc") @key(fields: "sku variation { id }") {
+	upc: String!
+	sku: String!
+	variation: Variation
+	reviews: [Review] @provides(fields: "body")
+}
+
+type Variation {
+	id: ID!
+}
+
+type Review {
+	body: String
+}
+
+type User @extends @key(fields: "id") {
+	id: ID! @external
+	email: String @external
+	name: String @requires(fields: "email")
+}`,
+		},
+		{
+			Name: "Invalid",
+			Src: `type Product @key(fields: "id") {
+	upc: String!
+	price: Int
+	weight: Int @external
+	shipping: Int @requires(fields: "price size")
+	reviews: [Review] @provides(fields: "title")
+	name: String @provides(fields: "title")
+}
+
+type Review {
+	body: String
+	author: String @external
+}`,
+			Errs: []string{
+				"Product: @key field does not exist: id",
+				"Product:shipping: @requires field must be marked @external: price",
+				"Product:shipping: @requires field does not exist: size",
+				"Product:reviews: @provides field does not exist on Review: title",
+				"Product:name: @provides can only be applied to fields which return an object type",
+				"Review:author: @external field must belong to an entity",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), testCase.Name, strings.NewReader(testCase.Src), 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+
+			errs := FederationValidator.Check(compiler.ToIR([]*ast.Document{doc}))
+			if len(errs) != len(testCase.Errs) {
+				subT.Errorf("expected %d errors but got: %v", len(testCase.Errs), errs)
+				return
+			}
+
+			for i, err := range errs {
+				if err.Error() != testCase.Errs[i] {
+					subT.Errorf("expected error: %s, but got: %s", testCase.Errs[i], err)
+				}
+			}
+		})
+	}
+}
+
+func TestRegisterFederation(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`type Product @key(fields: "upc") @key(fields: "sku") {
+	upc: String!
+	sku: String!
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reg := compiler.NewRegistry(compiler.GlobalRegistry())
+	RegisterFederation(reg)
+
+	errs, err := compiler.CheckTypesContext(compiler.WithRegistry(context.Background(), reg), compiler.ToIR([]*ast.Document{doc}), 0, NewValidator(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("expected no errors but got: %v", errs)
+	}
+
+	if compiler.IsRepeatable("key") {
+		t.Error("expected @key to only be registered with the given Registry")
+	}
+}
+
+func TestFederate(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`type Query {
+	me: User
+}
+
+type User @key(fields: "id") {
+	id: ID!
+}
+
+type Product @key(fields: "upc") {
+	upc: String!
+}`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ir := Federate(compiler.ToIR([]*ast.Document{doc}))
+
+	entity, ok := ir[doc]["_Entity"]
+	if !ok {
+		t.Error("expected _Entity union to be declared")
+		return
+	}
+
+	var members []string
+	for _, m := range entity[0].Spec.(*ast.TypeDecl_TypeSpec).TypeSpec.Type.(*ast.TypeSpec_Union).Union.Members {
+		members = append(members, m.Name)
+	}
+	if s := strings.Join(members, " | "); s != "User | Product" {
+		t.Errorf("expected _Entity members: User | Product, but got: %s", s)
+	}
+
+	query := ir[doc]["Query"]
+	if len(query) != 2 {
+		t.Errorf("expected Query to be extended but got: %d declarations", len(query))
+		return
+	}
+
+	var fields []string
+	ext := query[1].Spec.(*ast.TypeDecl_TypeExtSpec).TypeExtSpec.Type
+	for _, f := range ext.Type.(*ast.TypeSpec_Object).Object.Fields.List {
+		fields = append(fields, f.Name.Name)
+	}
+	if s := strings.Join(fields, ", "); s != "_entities, _service" {
+		t.Errorf("expected Query fields: _entities, _service, but got: %s", s)
+	}
+}
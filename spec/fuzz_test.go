@@ -0,0 +1,27 @@
+//go:build go1.18
+// +build go1.18
+
+package spec
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/compiler/internal/fuzz"
+)
+
+// FuzzValidator checks that Validator doesn't panic on any decoded set of Documents.
+func FuzzValidator(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 4, 2, 0, 1, 2, 2, 1, 0, 1, 3, 0, 0})
+	f.Add([]byte{2, 0, 3, 2, 1, 4, 0, 2, 1, 1, 0, 0, 1, 0, 2, 1, 3, 0, 0, 0})
+
+	reg := compiler.NewRegistry(nil)
+	reg.RegisterTypes(BuiltinTypes...)
+	ctx := compiler.WithRegistry(context.Background(), reg)
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		compiler.CheckTypesContext(ctx, compiler.ToIR(fuzz.Documents(data)), 0, NewValidator(0))
+	})
+}
@@ -0,0 +1,118 @@
+package spec
+
+import (
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+)
+
+// IntrospectionTypes contains the types of the introspection system, as
+// defined by the GraphQL spec, e.g. __Schema and __Type.
+//
+var IntrospectionTypes []*ast.TypeDecl
+
+func init() {
+	var err error
+	IntrospectionTypes, err = compiler.ParseTypes(introspection)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RegisterIntrospection registers the IntrospectionTypes with r, so schemas
+// which reference them, e.g. those of GraphQL tooling, can be validated.
+// Introspection types are opt-in, since most generators should not output
+// them.
+//
+func RegisterIntrospection(r *compiler.Registry) {
+	r.RegisterTypes(IntrospectionTypes...)
+}
+
+// introspection is the SDL of the introspection system.
+const introspection = `type __Schema {
+  description: String
+  types: [__Type!]!
+  queryType: __Type!
+  mutationType: __Type
+  subscriptionType: __Type
+  directives: [__Directive!]!
+}
+
+type __Type {
+  kind: __TypeKind!
+  name: String
+  description: String
+  specifiedByURL: String
+  fields(includeDeprecated: Boolean = false): [__Field!]
+  interfaces: [__Type!]
+  possibleTypes: [__Type!]
+  enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
+  inputFields(includeDeprecated: Boolean = false): [__InputValue!]
+  ofType: __Type
+}
+
+enum __TypeKind {
+  SCALAR
+  OBJECT
+  INTERFACE
+  UNION
+  ENUM
+  INPUT_OBJECT
+  LIST
+  NON_NULL
+}
+
+type __Field {
+  name: String!
+  description: String
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  type: __Type!
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __InputValue {
+  name: String!
+  description: String
+  type: __Type!
+  defaultValue: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __EnumValue {
+  name: String!
+  description: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __Directive {
+  name: String!
+  description: String
+  locations: [__DirectiveLocation!]!
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  isRepeatable: Boolean!
+}
+
+enum __DirectiveLocation {
+  QUERY
+  MUTATION
+  SUBSCRIPTION
+  FIELD
+  FRAGMENT_DEFINITION
+  FRAGMENT_SPREAD
+  INLINE_FRAGMENT
+  VARIABLE_DEFINITION
+  SCHEMA
+  SCALAR
+  OBJECT
+  FIELD_DEFINITION
+  ARGUMENT_DEFINITION
+  INTERFACE
+  UNION
+  ENUM
+  ENUM_VALUE
+  INPUT_OBJECT
+  INPUT_FIELD_DEFINITION
+}
+`
@@ -0,0 +1,53 @@
+package spec
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gqlc/compiler"
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestIntrospectionTypes(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`type Query {
+	schema: __Schema!
+	type(name: String!): __Type
+	kinds: [__TypeKind!]!
+}`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	testCases := []struct {
+		Name     string
+		Register bool
+		Errs     int
+	}{
+		{Name: "Unregistered", Errs: 3},
+		{Name: "Registered", Register: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			reg := compiler.NewRegistry(nil)
+			reg.RegisterTypes(BuiltinTypes...)
+			if testCase.Register {
+				RegisterIntrospection(reg)
+			}
+			ctx := compiler.WithRegistry(context.Background(), reg)
+
+			errs, err := compiler.CheckTypesContext(ctx, compiler.ToIR([]*ast.Document{doc}), 0, NewValidator(0))
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			if len(errs) != testCase.Errs {
+				subT.Errorf("expected %d errors but got: %v", testCase.Errs, errs)
+			}
+		})
+	}
+}
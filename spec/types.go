@@ -171,6 +171,35 @@ var BuiltinTypes = []*ast.TypeDecl{
 			},
 		},
 	},
+	{
+		Tok: token.Token_DIRECTIVE,
+		Spec: &ast.TypeDecl_TypeSpec{
+			TypeSpec: &ast.TypeSpec{
+				Name: &ast.Ident{Name: "specifiedBy"},
+				Type: &ast.TypeSpec_Directive{
+					Directive: &ast.DirectiveType{
+						Args: &ast.InputValueList{
+							List: []*ast.InputValue{
+								{
+									Name: &ast.Ident{Name: "url"},
+									Type: &ast.InputValue_NonNull{
+										NonNull: &ast.NonNull{
+											Type: &ast.NonNull_Ident{Ident: &ast.Ident{Name: "String"}},
+										},
+									},
+								},
+							},
+						},
+						Locs: []*ast.DirectiveLocation{
+							{
+								Loc: ast.DirectiveLocation_SCALAR,
+							},
+						},
+					},
+				},
+			},
+		},
+	},
 }
 
 func init() {
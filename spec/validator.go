@@ -1,8 +1,11 @@
 package spec
 
 import (
+	"context"
 	"fmt"
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/gqlc/compiler"
 	"github.com/gqlc/graphql/ast"
@@ -10,65 +13,240 @@ import (
 )
 
 // Validator uses the rules defined in the GraphQL spec to validates types.
-var Validator = compiler.TypeCheckerFn(validate)
+// Types are validated concurrently, by up to GOMAXPROCS goroutines.
+//
+// Validator always validates every type against the global Registry. Use
+// NewValidator to stop early with compiler.CheckTypesN or to use the
+// Registry and cancellation of compiler.CheckTypesContext.
+//
+var Validator = compiler.TypeCheckerFn(func(ir compiler.IR) []error {
+	return validate(context.Background(), ir, 0, 0, Draft)
+})
+
+// NewValidator returns a Validator which validates types concurrently
+// by up to workers goroutines. If workers <= 0, GOMAXPROCS is used.
+// It implements compiler.LimitedTypeChecker and compiler.ContextTypeChecker,
+// so it can stop early when used with compiler.CheckTypesN or
+// compiler.CheckTypesContext.
+//
+func NewValidator(workers int) compiler.LimitedTypeChecker {
+	return validator{edition: Draft, workers: workers}
+}
+
+// NewEditionValidator returns a Validator which only allows the features
+// supported by the given edition of the GraphQL spec, e.g. repeatable
+// directives are reported as errors by June2018. Interfaces implementing
+// interfaces can't be declared with the parser yet, so aren't checked.
+//
+func NewEditionValidator(edition Edition, workers int) compiler.LimitedTypeChecker {
+	return validator{edition: edition, workers: workers}
+}
+
+type validator struct {
+	edition Edition
+	workers int
+}
+
+func (v validator) Check(ir compiler.IR) []error {
+	return validate(context.Background(), ir, 0, v.workers, v.edition)
+}
+
+func (v validator) CheckN(ir compiler.IR, n int) []error {
+	return validate(context.Background(), ir, n, v.workers, v.edition)
+}
+
+func (v validator) CheckContext(ctx context.Context, ir compiler.IR, n int) []error {
+	return validate(ctx, ir, n, v.workers, v.edition)
+}
 
 type typeDecls struct {
-	ir    compiler.IR
-	types map[string][]*ast.TypeDecl
+	ir       compiler.IR
+	doc      *ast.Document
+	index    compiler.IndexSnapshot
+	registry *compiler.Registry
+	types    map[string][]*ast.TypeDecl
+	edition  Edition
+}
+
+func (decls typeDecls) isRepeatable(name string) bool {
+	if decls.registry != nil {
+		return decls.registry.IsRepeatable(name)
+	}
+	return compiler.IsRepeatable(name)
 }
 
+// lookup returns the declarations of the named type, preferring those of
+// the Document being validated. If that Document only extends the type,
+// the declarations of the Document which defines it are returned instead.
+//
 func (decls typeDecls) lookup(name string) []*ast.TypeDecl {
-	decl, ok := decls.types[name]
-	if ok {
-		return decl
+	local, ok := decls.types[name]
+	if ok && definition(local) != nil {
+		return local
+	}
+
+	var decl []*ast.TypeDecl
+	if decls.index != nil {
+		_, decl = decls.index.Lookup(name)
+	} else {
+		_, decl = compiler.Lookup(name, decls.ir)
 	}
 
-	_, decl = compiler.Lookup(name, decls.ir)
+	if decl == nil {
+		return local
+	}
 	return decl
 }
 
-func validate(ir compiler.IR) (errs []error) {
-	for doc, types := range ir {
-		typeDecl := typeDecls{types: types, ir: ir}
+// definition returns the TypeSpec of the first type definition in decls,
+// or nil if decls only holds extensions.
+//
+func definition(decls []*ast.TypeDecl) *ast.TypeSpec {
+	for _, decl := range decls {
+		if ts, ok := decl.Spec.(*ast.TypeDecl_TypeSpec); ok {
+			return ts.TypeSpec
+		}
+	}
+	return nil
+}
 
-		for name, decls := range types {
-			decl := decls[0]
+// validate validates the IR against the given edition of the spec, stopping
+// once n errors have been found, or ctx is done. If n <= 0 then all errors
+// are returned.
+//
+// Each type, and the top-level directives of each Document, are validated
+// concurrently by up to workers goroutines. Errors are merged in Document,
+// then type, order, so the result is the same as validating serially.
+//
+func validate(ctx context.Context, ir compiler.IR, n, workers int, edition Edition) []error {
+	index := compiler.NewIndexSnapshot(ir)
+	registry := compiler.RegistryFrom(ctx)
 
-			// Make sure the front is a TypeSpec and not an TypeExt
-			ts, ok := decl.Spec.(*ast.TypeDecl_TypeSpec)
-			if !ok {
-				errs = append(errs, fmt.Errorf("missing type declaration for: %s", name))
-				continue
-			}
+	var jobs []func(*[]error)
+	for _, doc := range ir.Documents() {
+		types := ir[doc]
+		typeDecl := typeDecls{types: types, ir: ir, doc: doc, index: index, registry: registry, edition: edition}
 
-			typ, loc := validateType(ts.TypeSpec, typeDecl, &errs)
+		// Registered types may use reserved names, e.g. the introspection types
+		builtin := compiler.IsBuiltins(doc)
 
-			// Check type name
-			if loc != ast.DirectiveLocation_SCHEMA {
-				checkName(typ, ts.TypeSpec.Name, &errs)
-			}
+		for _, name := range compiler.TypeNames(types) {
+			name, decls := name, types[name]
+			jobs = append(jobs, func(errs *[]error) { validateDecls(name, decls, builtin, typeDecl, errs) })
+		}
 
-			// Validate applied directives
-			if loc != ast.DirectiveLocation_NoPos {
-				validateDirectives(ts.TypeSpec.Directives, loc, typeDecl, &errs)
-			}
+		// Validate top-lvl directives
+		dirs := doc.Directives
+		jobs = append(jobs, func(errs *[]error) {
+			validateDirectives(dirs, ast.DirectiveLocation_DOCUMENT, typeDecl, errs)
+		})
+	}
 
-			for _, decl = range decls[1:] {
-				exts, ok := decl.Spec.(*ast.TypeDecl_TypeExtSpec)
-				if !ok {
-					errs = append(errs, fmt.Errorf("cannot have more than one type definition for: %s", name))
-					continue
-				}
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
 
-				validateExtend(ts.TypeSpec, exts.TypeExtSpec.Type, typeDecl, &errs)
-			}
+	// Jobs are started in order and stop being started once the jobs
+	// completed in order have found n errors, or ctx is done.
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		results  = make([][]error, len(jobs))
+		done     = make([]bool, len(jobs))
+		merged   int
+		found    int
+		sem      = make(chan struct{}, workers)
+		panicked interface{}
+	)
+	for i, job := range jobs {
+		mu.Lock()
+		stop := n > 0 && found >= n
+		mu.Unlock()
+		if stop || ctx.Err() != nil {
+			break
 		}
 
-		// Validate top-lvl directives
-		validateDirectives(doc.Directives, ast.DirectiveLocation_DOCUMENT, typeDecl, &errs)
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, job func(*[]error)) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var errs []error
+			defer func() {
+				r := recover()
+
+				mu.Lock()
+				defer mu.Unlock()
+				if r != nil && panicked == nil {
+					panicked = r
+				}
+
+				results[i], done[i] = errs, true
+				for ; merged < len(jobs) && done[merged]; merged++ {
+					found += len(results[merged])
+				}
+			}()
+
+			job(&errs)
+		}(i, job)
 	}
+	wg.Wait()
 
-	return
+	// Panics are raised again on the calling goroutine, with the value
+	// recovered from the job, as if validating serially, so that they can
+	// be recovered by the caller.
+	if panicked != nil {
+		panic(panicked)
+	}
+
+	var errs []error
+	for _, r := range results {
+		errs = append(errs, r...)
+	}
+	if n > 0 && len(errs) > n {
+		errs = errs[:n]
+	}
+	return errs
+}
+
+// validateDecls validates a type declaration along with its extensions.
+func validateDecls(name string, decls []*ast.TypeDecl, builtin bool, typeDecl typeDecls, errs *[]error) {
+	decl := decls[0]
+
+	// Make sure the front is a TypeSpec and not an TypeExt
+	ts, ok := decl.Spec.(*ast.TypeDecl_TypeSpec)
+	if !ok {
+		*errs = append(*errs, fmt.Errorf("missing type declaration for: %s", name))
+		return
+	}
+
+	typ, loc := validateType(ts.TypeSpec, typeDecl, errs)
+
+	// Check type name
+	if loc != ast.DirectiveLocation_SCHEMA && !builtin {
+		checkName(typ, ts.TypeSpec.Name, errs)
+	}
+
+	// Validate applied directives
+	if loc != ast.DirectiveLocation_NoPos {
+		validateDirectives(ts.TypeSpec.Directives, loc, typeDecl, errs)
+	}
+
+	validateEdition(decl, loc, typeDecl, errs)
+
+	if derr := compiler.NewDuplicateError(typeDecl.ir, typeDecl.doc, name); derr != nil {
+		*errs = append(*errs, derr)
+	}
+
+	for _, decl = range decls[1:] {
+		exts, ok := decl.Spec.(*ast.TypeDecl_TypeExtSpec)
+		if !ok {
+			continue
+		}
+
+		validateExtend(ts.TypeSpec, exts.TypeExtSpec.Type, typeDecl, errs)
+	}
 }
 
 func validateType(ts *ast.TypeSpec, decls typeDecls, errs *[]error) (typ token.Token, loc ast.DirectiveLocation_Loc) {
@@ -187,12 +365,13 @@ func validateEnum(name string, enum *ast.EnumType, items typeDecls, errs *[]erro
 		validateDirectives(v.Directives, ast.DirectiveLocation_ENUM_VALUE, items, errs)
 	}
 
-	for v, c := range vMap {
-		if c == 1 {
+	for _, v := range enum.Values.List {
+		if vMap[v.Name.Name] <= 1 {
 			continue
 		}
+		vMap[v.Name.Name] = 0
 
-		*errs = append(*errs, fmt.Errorf("%s:%s: enum value must be unique", name, v))
+		*errs = append(*errs, fmt.Errorf("%s:%s: enum value must be unique", name, v.Name.Name))
 	}
 }
 
@@ -213,7 +392,13 @@ func validateUnion(name string, union *ast.UnionType, items typeDecls, errs *[]e
 		vMap[v.Name] = c + 1
 	}
 
-	for v, c := range vMap {
+	for _, m := range union.Members {
+		v, c := m.Name, vMap[m.Name]
+		if c == 0 {
+			continue
+		}
+		vMap[v] = 0
+
 		decls := items.lookup(v)
 		if decls == nil {
 			*errs = append(*errs, fmt.Errorf("%s:%s: undefined type", name, v))
@@ -257,7 +442,15 @@ func validateArgDefs(name string, args []*ast.InputValue, items typeDecls, errs
 		aMap[f.Name.Name] = i
 	}
 
-	for aname, a := range aMap {
+	seen := make(map[string]bool, len(aMap))
+	for _, f := range args {
+		aname := f.Name.Name
+		if seen[aname] {
+			continue
+		}
+		seen[aname] = true
+		a := aMap[aname]
+
 		// Ensure field uniqueness
 		if a.count > 1 {
 			*errs = append(*errs, fmt.Errorf("%s:%s: argument must be unique", name, aname))
@@ -326,7 +519,15 @@ func validateFields(name string, fields []*ast.Field, items typeDecls, errs *[]e
 		fMap[f.Name.Name] = i
 	}
 
-	for fname, f := range fMap {
+	seen := make(map[string]bool, len(fMap))
+	for _, field := range fields {
+		fname := field.Name.Name
+		if seen[fname] {
+			continue
+		}
+		seen[fname] = true
+		f := fMap[fname]
+
 		// Ensure field uniqueness
 		if f.count > 1 {
 			*errs = append(*errs, fmt.Errorf("%s:%s: field must be unique", name, fname))
@@ -393,6 +594,70 @@ func validateInput(name string, input *ast.InputType, items typeDecls, errs *[]e
 	}
 
 	validateArgDefs(name, input.Fields.List, items, errs)
+
+	// Non-null input fields must not form a cycle, since the type could never be constructed.
+	// Each cycle is only reported by the type, in it, whose name sorts first.
+	for _, path := range findInputCycles(name, items) {
+		*errs = append(*errs, fmt.Errorf("%s: input object can not reference itself through non-null fields: %s", name, strings.Join(append(path, name), " -> ")))
+	}
+}
+
+// findInputCycles returns every path of non-null, non-list input fields from the input object, start, back to
+// itself, which only passes through input objects whose names sort after start. Every cycle is thereby found
+// once, starting from the type, in it, whose name sorts first. Paths are returned as lists of "Type.field" hops.
+func findInputCycles(start string, items typeDecls) (cycles [][]string) {
+	seen := make(map[string]bool)
+	onPath := map[string]bool{start: true}
+	var path []string
+
+	var visit func(cur string)
+	visit = func(cur string) {
+		for _, decl := range items.lookup(cur) {
+			var ts *ast.TypeSpec
+			switch v := decl.Spec.(type) {
+			case *ast.TypeDecl_TypeSpec:
+				ts = v.TypeSpec
+			case *ast.TypeDecl_TypeExtSpec:
+				ts = v.TypeExtSpec.Type
+			}
+
+			input, ok := ts.Type.(*ast.TypeSpec_Input)
+			if !ok || input.Input.Fields == nil {
+				continue
+			}
+
+			for _, f := range input.Input.Fields.List {
+				nn, ok := f.Type.(*ast.InputValue_NonNull)
+				if !ok {
+					continue
+				}
+
+				id, ok := nn.NonNull.Type.(*ast.NonNull_Ident)
+				if !ok {
+					continue
+				}
+
+				hop := fmt.Sprintf("%s.%s", cur, f.Name.Name)
+				next := id.Ident.Name
+				switch {
+				case next == start:
+					cycle := append(append([]string(nil), path...), hop)
+					if key := strings.Join(cycle, " "); !seen[key] {
+						seen[key] = true
+						cycles = append(cycles, cycle)
+					}
+				case next > start && !onPath[next]:
+					onPath[next] = true
+					path = append(path, hop)
+					visit(next)
+					path = path[:len(path)-1]
+					onPath[next] = false
+				}
+			}
+		}
+	}
+	visit(start)
+	return
 }
 
 // validateObject validates an object declaration
@@ -443,6 +708,67 @@ func validateObject(name string, object *ast.ObjectType, items typeDecls, errs *
 	}
 }
 
+type implementor struct {
+	name   string
+	fields map[string]struct {
+		field *ast.Field
+		count int
+	}
+}
+
+// implementors returns every object type which implements the interface, along with the fields
+// of the object and all of its extensions.
+func implementors(inter string, items typeDecls) (objs []implementor) {
+	for _, doc := range items.ir.Documents() {
+		types := items.ir[doc]
+		for _, name := range compiler.TypeNames(types) {
+			obj := implementor{name: name, fields: make(map[string]struct {
+				field *ast.Field
+				count int
+			})}
+
+			var implements bool
+			for _, decl := range types[name] {
+				var ts *ast.TypeSpec
+				switch v := decl.Spec.(type) {
+				case *ast.TypeDecl_TypeSpec:
+					ts = v.TypeSpec
+				case *ast.TypeDecl_TypeExtSpec:
+					ts = v.TypeExtSpec.Type
+				}
+
+				o, ok := ts.Type.(*ast.TypeSpec_Object)
+				if !ok {
+					continue
+				}
+
+				for _, i := range o.Object.Interfaces {
+					implements = implements || i.Name == inter
+				}
+
+				if o.Object.Fields == nil {
+					continue
+				}
+				for _, f := range o.Object.Fields.List {
+					if _, exists := obj.fields[f.Name.Name]; exists {
+						continue
+					}
+
+					obj.fields[f.Name.Name] = struct {
+						field *ast.Field
+						count int
+					}{field: f, count: 1}
+				}
+			}
+
+			if implements {
+				objs = append(objs, obj)
+			}
+		}
+	}
+	return
+}
+
 // validateInterfaceFields validates an objects field set satisfies an interfaces field set
 func validateInterfaceFields(objName, interName string, objFields map[string]struct {
 	field *ast.Field
@@ -552,7 +878,14 @@ func validateInterfaceFields(objName, interName string, objFields map[string]str
 
 		// 3. The object field may include additional arguments not defined in the interface field, but any
 		// 	  additional argument must not be required, i.e. must not be of a non‐nullable type.
-		for oaName, oaType := range aMap {
+		for _, oa := range objField.field.Args.List {
+			oaName := oa.Name.Name
+			oaType, ok := aMap[oaName]
+			if !ok {
+				continue
+			}
+			delete(aMap, oaName)
+
 			if _, ok := oaType.(*ast.NonNull); ok {
 				*errs = append(*errs, fmt.Errorf("%s:%s:%s: additional arguments to interface field implementation must be non-null", objName, fname, oaName))
 			}
@@ -570,28 +903,28 @@ func compareTypes(a, b interface{}, items typeDecls) bool {
 			return true
 		}
 
-		ad := items.lookup(ai.Name)[0]
-		bd := items.lookup(bi.Name)[0]
-
 		// Check if a is a sub-type of b through interface implementation
-		at := ad.Spec.(*ast.TypeDecl_TypeSpec)
-		bt := bd.Spec.(*ast.TypeDecl_TypeSpec)
+		at := definition(items.lookup(ai.Name))
+		bt := definition(items.lookup(bi.Name))
+		if at == nil || bt == nil {
+			return false
+		}
 
-		aObj, ok := at.TypeSpec.Type.(*ast.TypeSpec_Object)
+		aObj, ok := at.Type.(*ast.TypeSpec_Object)
 		if !ok {
 			return false
 		}
 
-		switch v := bt.TypeSpec.Type.(type) {
+		switch v := bt.Type.(type) {
 		case *ast.TypeSpec_Interface:
 			for _, i := range aObj.Object.Interfaces {
-				if i.Name == bt.TypeSpec.Name.Name {
+				if i.Name == bi.Name {
 					return true
 				}
 			}
 		case *ast.TypeSpec_Union:
 			for _, m := range v.Union.Members {
-				if m.Name == at.TypeSpec.Name.Name {
+				if m.Name == ai.Name {
 					return true
 				}
 			}
@@ -705,7 +1038,14 @@ func validateExtend(ogts, exts *ast.TypeSpec, items typeDecls, errs *[]error) {
 		if t.Object.Fields != nil {
 			efMap := validateFields(name, t.Object.Fields.List, items, errs)
 
-			for efName, ef := range efMap {
+			for _, f := range t.Object.Fields.List {
+				efName := f.Name.Name
+				ef, ok := efMap[efName]
+				if !ok {
+					continue
+				}
+				delete(efMap, efName)
+
 				if _, ok := fMap[efName]; ok {
 					*errs = append(*errs, fmt.Errorf("%s:%s: field definition already exists in original object definition", name, efName))
 					continue
@@ -773,8 +1113,11 @@ func validateExtend(ogts, exts *ast.TypeSpec, items typeDecls, errs *[]error) {
 			}
 		}
 
-		// TODO: Any object type which implemented the original interface type must also be a super-set
-		// 		 of the fields of the interface type extension (which may be due to object type extension)
+		// Any object type which implemented the original interface type must also be a super-set
+		// of the fields of the interface type extension (which may be due to object type extension)
+		for _, obj := range implementors(exts.Name.Name, items) {
+			validateInterfaceFields(obj.name, name, obj.fields, t.Interface.Fields.List, items, errs)
+		}
 	case *ast.TypeSpec_Union:
 		ogUnion, ok := ogts.Type.(*ast.TypeSpec_Union)
 		if !ok {
@@ -911,10 +1254,103 @@ func validateDirective(name string, directive *ast.DirectiveType, items typeDecl
 			validateDirectives(f.Directives, ast.DirectiveLocation_ARGUMENT_DEFINITION, items, errs)
 		}
 
-		// TODO: 5. Check that the arg Type doesn't reference this directive
+		// 5. Check that the arg Type doesn't reference this directive
+		if id == nil {
+			continue
+		}
+		if chain := findDirectiveRef(name, id.Name, items, make(map[string]bool)); chain != nil {
+			*errs = append(*errs, fmt.Errorf("%s:%s: directive argument type cannot reference its own directive definition: %s", name, f.Name.Name, strings.Join(chain, " -> ")))
+		}
 	}
 }
 
+// findDirectiveRef searches the type, typ, and any types or directive definitions transitively reachable from it
+// for an application of the directive, dir. The chain of references is returned, ending with the directive.
+func findDirectiveRef(dir, typ string, items typeDecls, visited map[string]bool) []string {
+	if visited[typ] {
+		return nil
+	}
+	visited[typ] = true
+
+	// follow searches the directives applied at hop, as well as any types they reference
+	var follow func(hop string, dirs []*ast.DirectiveLit) []string
+	follow = func(hop string, dirs []*ast.DirectiveLit) []string {
+		for _, d := range dirs {
+			if d.Name == dir {
+				return []string{hop, "@" + dir}
+			}
+		}
+
+		for _, d := range dirs {
+			if chain := findDirectiveRef(dir, "@"+d.Name, items, visited); chain != nil {
+				return append([]string{hop}, chain...)
+			}
+		}
+		return nil
+	}
+
+	name := strings.TrimPrefix(typ, "@")
+	for _, decl := range items.lookup(name) {
+		var ts *ast.TypeSpec
+		switch v := decl.Spec.(type) {
+		case *ast.TypeDecl_TypeSpec:
+			ts = v.TypeSpec
+		case *ast.TypeDecl_TypeExtSpec:
+			ts = v.TypeExtSpec.Type
+		}
+
+		if chain := follow(typ, ts.Directives); chain != nil {
+			return chain
+		}
+
+		var args *ast.InputValueList
+		switch v := ts.Type.(type) {
+		case *ast.TypeSpec_Enum:
+			if v.Enum.Values == nil {
+				break
+			}
+
+			for _, ev := range v.Enum.Values.List {
+				if chain := follow(typ+"."+ev.Name.Name, ev.Directives); chain != nil {
+					return chain
+				}
+			}
+		case *ast.TypeSpec_Input:
+			args = v.Input.Fields
+		case *ast.TypeSpec_Directive:
+			args = v.Directive.Args
+		}
+		if args == nil {
+			continue
+		}
+
+		for _, a := range args.List {
+			hop := typ + "." + a.Name.Name
+			if chain := follow(hop, a.Directives); chain != nil {
+				return chain
+			}
+
+			var id *ast.Ident
+			switch v := a.Type.(type) {
+			case *ast.InputValue_Ident:
+				id = v.Ident
+			case *ast.InputValue_List:
+				id = unwrapType(v.List)
+			case *ast.InputValue_NonNull:
+				id = unwrapType(v.NonNull)
+			}
+			if id == nil {
+				continue
+			}
+
+			if chain := findDirectiveRef(dir, id.Name, items, visited); chain != nil {
+				return append([]string{hop}, chain...)
+			}
+		}
+	}
+	return nil
+}
+
 // validateArgs validates a list of args. host can either be
 func validateArgs(host string, argDefs []*ast.InputValue, args []*ast.Arg, items typeDecls, errs *[]error) {
 	argMap := make(map[string]struct {
@@ -982,8 +1418,13 @@ func validateArgs(host string, argDefs []*ast.InputValue, args []*ast.Arg, items
 	}
 
 	// Args must exist
-	for arg := range argMap {
-		*errs = append(*errs, fmt.Errorf("%s: undefined arg: %s", host, arg))
+	for _, arg := range args {
+		if _, ok := argMap[arg.Name.Name]; !ok {
+			continue
+		}
+		delete(argMap, arg.Name.Name)
+
+		*errs = append(*errs, fmt.Errorf("%s: undefined arg: %s", host, arg.Name.Name))
 	}
 }
 
@@ -1322,8 +1763,13 @@ func validateObj(host, arg string, fieldDefs []*ast.InputValue, objFields []*ast
 	}
 
 	// Fields must exist
-	for _, f := range objFieldMap {
-		*errs = append(*errs, fmt.Errorf("%s:%s: undefined field: %s", host, arg, f.objField.Key.Name))
+	for _, f := range objFields {
+		if _, ok := objFieldMap[f.Key.Name]; !ok {
+			continue
+		}
+		delete(objFieldMap, f.Key.Name)
+
+		*errs = append(*errs, fmt.Errorf("%s:%s: undefined field: %s", host, arg, f.Key.Name))
 	}
 }
 
@@ -1347,7 +1793,15 @@ func validateDirectives(directives []*ast.DirectiveLit, loc ast.DirectiveLocatio
 		dirMap[dirLit.Name] = i
 	}
 
-	for name, d := range dirMap {
+	seen := make(map[string]bool, len(dirMap))
+	for _, dirLit := range directives {
+		name := dirLit.Name
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		d := dirMap[name]
+
 		// 1: Directive definition must exist
 		decls := items.lookup(name)
 		if decls == nil {
@@ -1364,7 +1818,13 @@ func validateDirectives(directives []*ast.DirectiveLit, loc ast.DirectiveLocatio
 
 		dirType := dirSpec.TypeSpec.Type.(*ast.TypeSpec_Directive).Directive
 
-		// 2: Directive must be applied in proper location
+		// 2: Directive must be supported by the edition
+		if since, ok := editionDirectives[name]; ok && !items.edition.allows(since) {
+			*errs = append(*errs, fmt.Errorf("%s: directive is not supported by the %s edition", name, items.edition))
+			continue
+		}
+
+		// 3: Directive must be applied in proper location
 		var validLoc bool
 		for _, l := range dirType.Locs {
 			if l.Loc == loc {
@@ -1376,16 +1836,27 @@ func validateDirectives(directives []*ast.DirectiveLit, loc ast.DirectiveLocatio
 			continue
 		}
 
-		// 3: Directives must be unique per location
-		if d.count > 1 {
+		// 4: Directives must be unique per location, unless repeatable. Document
+		// directives aren't part of the spec, so don't depend on its edition.
+		repeatable := items.isRepeatable(name) && (loc == ast.DirectiveLocation_DOCUMENT || items.edition.allows(October2021))
+		if d.count > 1 && !repeatable {
 			*errs = append(*errs, fmt.Errorf("%s: directive cannot be applied more than once per location: %s", name, loc))
 		}
 
-		// 4: Directive arguments must be valid
-		if dirType.Args == nil || d.dirLit.Args == nil {
+		// 5: Directive arguments must be valid
+		if dirType.Args == nil {
 			continue
 		}
-		validateArgs(name, dirType.Args.List, d.dirLit.Args.Args, items, errs)
+		for _, lit := range directives {
+			if lit.Name != name || lit.Args == nil {
+				continue
+			}
+			validateArgs(name, dirType.Args.List, lit.Args.Args, items, errs)
+
+			if !repeatable {
+				break
+			}
+		}
 	}
 }
 
@@ -1,7 +1,11 @@
 package spec
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -479,8 +483,26 @@ func TestDirectives(t *testing.T) {
 				fmt.Sprintf("%s: directive cannot be applied more than once per location: %s", "test", ast.DirectiveLocation_FIELD),
 			},
 		},
+		{
+			Name: "Repeatable",
+			Dirs: []*ast.DirectiveLit{{Name: "repeat"}, {Name: "repeat"}},
+			Loc:  ast.DirectiveLocation_FIELD,
+			Items: []*ast.TypeDecl{
+				{
+					Spec: &ast.TypeDecl_TypeSpec{TypeSpec: &ast.TypeSpec{
+						Name: &ast.Ident{Name: "repeat"},
+						Type: &ast.TypeSpec_Directive{Directive: &ast.DirectiveType{
+							Locs: []*ast.DirectiveLocation{{Loc: ast.DirectiveLocation_FIELD}},
+						}},
+					}},
+				},
+			},
+		},
 	}
 
+	reg := compiler.NewRegistry(compiler.GlobalRegistry())
+	reg.RegisterRepeatable("repeat")
+
 	ir := make(compiler.IR)
 	for _, testCase := range testCases {
 		t.Run(testCase.Name, func(subT *testing.T) {
@@ -488,7 +510,7 @@ func TestDirectives(t *testing.T) {
 			validateDirectives(
 				testCase.Dirs,
 				testCase.Loc,
-				typeDecls{types: toDeclMap(testCase.Items), ir: ir},
+				typeDecls{types: toDeclMap(testCase.Items), ir: ir, registry: reg},
 				&errs,
 			)
 
@@ -680,6 +702,58 @@ scalar String`,
 				fmt.Sprintf("%s: input object type must define one or more input fields", "A"),
 			},
 		},
+		{
+			Name: "Input:Cycle",
+			Src: `input A {
+	b: B!
+}
+
+input B {
+	c: C!
+	d: D
+}
+
+input C {
+	a: A!
+}
+
+input D {
+	d: D!
+}
+
+input E {
+	e: E
+	f: [E!]!
+}`,
+			Errs: []string{
+				fmt.Sprintf("%s: input object can not reference itself through non-null fields: %s", "A", "A.b -> B.c -> C.a -> A"),
+				fmt.Sprintf("%s: input object can not reference itself through non-null fields: %s", "D", "D.d -> D"),
+			},
+		},
+		{
+			Name: "Input:Cycles",
+			Src: `input A {
+	b: B!
+	c: C!
+}
+
+input B {
+	a: A!
+	c: C!
+}
+
+input C {
+	a: A!
+	b: B!
+}`,
+			Errs: []string{
+				fmt.Sprintf("%s: input object can not reference itself through non-null fields: %s", "A", "A.b -> B.a -> A"),
+				fmt.Sprintf("%s: input object can not reference itself through non-null fields: %s", "A", "A.b -> B.c -> C.a -> A"),
+				fmt.Sprintf("%s: input object can not reference itself through non-null fields: %s", "A", "A.c -> C.a -> A"),
+				fmt.Sprintf("%s: input object can not reference itself through non-null fields: %s", "A", "A.c -> C.b -> B.a -> A"),
+				fmt.Sprintf("%s: input object can not reference itself through non-null fields: %s", "B", "B.c -> C.b -> B"),
+			},
+		},
 		{
 			Name: "Schema",
 			Src: `schema {}
@@ -761,6 +835,33 @@ interface Test {
 				fmt.Sprintf("%s:%s: directive argument cannont reference its own directive definition", "test", "__a"),
 			},
 		},
+		{
+			Name: "Directive:IndirectReference",
+			Src: `directive @a(one: A, two: B, three: C, four: S) on ARGUMENT_DEFINITION | ENUM_VALUE
+
+directive @b(b: C) on SCALAR
+
+scalar S @b
+
+input A {
+	b: B
+}
+
+input B {
+	c: C
+}
+
+enum C {
+	ONE @a
+}`,
+			Errs: []string{
+				fmt.Sprintf("%s:%s: directive argument type cannot reference its own directive definition: %s", "a", "one", "A.b -> B.c -> C.ONE -> @a"),
+				fmt.Sprintf("%s:%s: directive argument type cannot reference its own directive definition: %s", "a", "two", "B.c -> C.ONE -> @a"),
+				fmt.Sprintf("%s:%s: directive argument type cannot reference its own directive definition: %s", "a", "three", "C.ONE -> @a"),
+				fmt.Sprintf("%s:%s: directive argument type cannot reference its own directive definition: %s", "a", "four", "S -> @b.b -> C.ONE -> @a"),
+				fmt.Sprintf("%s:%s: directive argument type cannot reference its own directive definition: %s", "b", "b", "C.ONE -> @a.four -> S -> @b"),
+			},
+		},
 		{
 			Name: "Extend:NoDefinitionFound",
 			Src:  `extend scalar String`,
@@ -822,6 +923,45 @@ extend interface Test {
 				fmt.Sprintf("%s:%s: field already exists in original interface definition", "extend:interface:Test", "a"),
 			},
 		},
+		{
+			Name: "Extend:Interface:Implementors",
+			Src: `scalar String
+
+interface Test {
+	a: String
+}
+
+extend interface Test {
+	b: String
+}
+
+type A implements Test {
+	a: String
+	b: String
+}
+
+type B implements Test {
+	a: String
+}
+
+extend type B {
+	b: String
+}
+
+type C implements Test {
+	a: String
+}
+
+type D {
+	a: String
+}
+
+extend type D implements Test`,
+			Errs: []string{
+				fmt.Sprintf("%s:%s: object type must include field: %s", "C", "extend:interface:Test", "b"),
+				fmt.Sprintf("%s:%s: object type must include field: %s", "D", "extend:interface:Test", "b"),
+			},
+		},
 		{
 			Name: "Extend:Union",
 			Src: `scalar String
@@ -932,10 +1072,239 @@ extend scalar String @a @b`,
 	}
 }
 
+func TestValidateN(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`scalar __A
+
+scalar __B
+
+scalar __C`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	for n, l := range map[int]int{0: 3, 1: 1, 2: 2, 5: 3} {
+		errs := NewValidator(0).CheckN(compiler.ToIR([]*ast.Document{doc}), n)
+		if len(errs) != l {
+			t.Errorf("expected %d errors with a limit of %d but got: %d", l, n, len(errs))
+		}
+	}
+}
+
+func TestValidateContext(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`scalar __A`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs := NewValidator(0).(compiler.ContextTypeChecker).CheckContext(ctx, compiler.ToIR([]*ast.Document{doc}), 0)
+	if len(errs) != 0 {
+		t.Errorf("expected validation to stop before any errors but got: %v", errs)
+	}
+}
+
+func TestValidateOrder(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(`scalar __C
+
+type __B {
+	__z: S
+	__y: S
+	__x: S
+}
+
+scalar S
+
+scalar __A`), 0)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	expected := []string{
+		"__C is an invalid name for type: SCALAR",
+		"__B:__z: field name cannot start with \"__\" (double underscore)",
+		"__B:__y: field name cannot start with \"__\" (double underscore)",
+		"__B:__x: field name cannot start with \"__\" (double underscore)",
+		"__B is an invalid name for type: TYPE",
+		"__A is an invalid name for type: SCALAR",
+	}
+
+	for i := 0; i < 10; i++ {
+		errs := Validator.Check(compiler.ToIR([]*ast.Document{doc}))
+		if len(errs) != len(expected) {
+			t.Errorf("expected %d errors but got: %v", len(expected), errs)
+			return
+		}
+
+		for j, err := range errs {
+			if err.Error() != expected[j] {
+				t.Errorf("expected error: %s, but got: %s", expected[j], err)
+				return
+			}
+		}
+	}
+}
+
+func TestValidateParallel(t *testing.T) {
+	var docs []*ast.Document
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("doc%02d", i)
+		doc, err := parser.ParseDoc(token.NewDocSet(), name, strings.NewReader(fmt.Sprintf(`scalar __A%d
+
+type __B%d {
+	__x: __C%d
+}
+
+scalar __C%d`, i, i, i, i)), 0)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		docs = append(docs, doc)
+	}
+	ir := compiler.ToIR(docs)
+
+	expected := NewValidator(1).Check(ir)
+	if len(expected) != 80 {
+		t.Errorf("expected 80 errors but got: %d", len(expected))
+		return
+	}
+
+	testCases := []struct {
+		Name    string
+		Workers int
+		N       int
+	}{
+		{Name: "Default", Workers: 0},
+		{Name: "Many", Workers: 8},
+		{Name: "N", Workers: 8, N: 13},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			want := expected
+			if testCase.N > 0 {
+				want = want[:testCase.N]
+			}
+
+			for i := 0; i < 10; i++ {
+				errs := NewValidator(testCase.Workers).CheckN(ir, testCase.N)
+				if len(errs) != len(want) {
+					subT.Errorf("expected %d errors but got: %d", len(want), len(errs))
+					return
+				}
+
+				for j, err := range errs {
+					if err.Error() != want[j].Error() {
+						subT.Errorf("expected error: %s, but got: %s", want[j], err)
+						return
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePanic(t *testing.T) {
+	doc := &ast.Document{Name: "a"}
+	ir := compiler.IR{doc: {"A": nil}}
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected panic to be raised on the calling goroutine")
+		}
+
+		err, ok := r.(runtime.Error)
+		if !ok || !strings.Contains(err.Error(), "index out of range") {
+			t.Errorf("expected the runtime error raised by the validation job but got: %#v", r)
+		}
+	}()
+
+	validate(context.Background(), ir, 0, 2, Draft)
+}
+
 func TestValidator(t *testing.T) {
 	compiler.TestTypeChecker(t, Validator)
 }
 
+// TestValidateExtensionOnly checks that types which are only extended by the
+// first Document, by name, are resolved to their definitions. It was found
+// by FuzzValidator.
+//
+func TestValidateExtensionOnly(t *testing.T) {
+	docs, err := parser.ParseDocs(token.NewDocSet(), map[string]io.Reader{
+		"a": strings.NewReader(`extend type Bar {
+	y: Int
+}`),
+		"b": strings.NewReader(`type Bar {
+	x: Int
+}`),
+		"c": strings.NewReader(`type Foo {
+	x: Int
+}
+
+interface I {
+	f: Foo
+}
+
+type T implements I {
+	f: Bar
+}`),
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := compiler.CheckTypes(compiler.ToIR(docs), Validator)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors but got: %v", errs)
+	}
+	if !strings.Contains(errs[0].Error(), "missing type declaration for: Bar") {
+		t.Errorf("expected missing declaration for the unimported extension but got: %s", errs[0])
+	}
+	if !strings.Contains(errs[1].Error(), "T:f: object field type must be a sub-type of interface field type") {
+		t.Errorf("expected sub-type error but got: %s", errs[1])
+	}
+}
+
+func TestValidateDuplicates(t *testing.T) {
+	doc, err := parser.ParseDoc(token.NewDocSet(), "a", strings.NewReader(`type User {
+	id: ID
+}
+
+type Query {
+	user: User
+}
+
+type User {
+	name: String
+}`), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := compiler.CheckTypes(compiler.ToIR([]*ast.Document{doc}), Validator)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error but got: %v", errs)
+	}
+
+	var derr *compiler.DuplicateError
+	if !errors.As(errs[0], &derr) {
+		t.Fatalf("expected a DuplicateError but got: %s", errs[0])
+	}
+	if derr.Error() != "compiler: User is defined more than once in: a, a" {
+		t.Errorf("unexpected error: %s", derr)
+	}
+	if len(derr.Defs) != 2 || derr.Defs[0].Pos == 0 || derr.Defs[0].Pos >= derr.Defs[1].Pos {
+		t.Errorf("expected positions of both definitions but got: %v", derr.Defs)
+	}
+}
+
 func toDeclMap(decls []*ast.TypeDecl) map[string][]*ast.TypeDecl {
 	m := make(map[string][]*ast.TypeDecl, len(decls))
 
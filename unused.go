@@ -0,0 +1,78 @@
+package compiler
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// UnusedChecker returns a TypeChecker which reports every type and directive
+// definition that isn't reachable from the schema root operations, see Prune,
+// as a warning. Builtin types, and directives which can only be applied by
+// operations, e.g. on FIELD, are never reported.
+//
+// Types and directives matching any of the allow patterns aren't reported
+// either. Patterns are matched against names with path.Match, e.g. Admin*.
+//
+func UnusedChecker(allow ...string) TypeChecker {
+	return TypeCheckerFn(func(ir IR) (errs []error) {
+		reachable := reachableTypes(ir, 0)
+
+		for _, doc := range ir.Documents() {
+			if IsBuiltins(doc) {
+				continue
+			}
+
+			types := ir[doc]
+			for _, name := range TypeNames(types) {
+				if reachable[name] || allowed(name, allow) {
+					continue
+				}
+
+				decl := types[name][0]
+				kind := "type"
+				if DeclTok(decl) == token.Token_DIRECTIVE {
+					if isExecutableDirective(decl) {
+						continue
+					}
+					kind = "directive"
+				}
+
+				errs = append(errs, &TypeError{
+					Doc:      doc,
+					Msg:      fmt.Sprintf("%s: unused %s", name, kind),
+					Severity: SeverityWarning,
+				})
+			}
+		}
+		return
+	})
+}
+
+func allowed(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isExecutableDirective reports whether a directive can be applied to
+// operations, which aren't part of the schema.
+//
+func isExecutableDirective(decl *ast.TypeDecl) bool {
+	dir, ok := TypeSpecOf(decl).Type.(*ast.TypeSpec_Directive)
+	if !ok {
+		return false
+	}
+
+	for _, l := range dir.Directive.Locs {
+		if l.Loc >= ast.DirectiveLocation_QUERY && l.Loc <= ast.DirectiveLocation_VARIABLE_DEFINITION {
+			return true
+		}
+	}
+	return false
+}
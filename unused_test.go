@@ -0,0 +1,75 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestUnusedChecker(t *testing.T) {
+	src := `directive @auth(role: Role) on FIELD_DEFINITION
+directive @cached on OBJECT
+directive @skipIf(cond: Boolean) on FIELD
+
+type Query {
+	user: User @auth(role: ADMIN)
+}
+
+type User {
+	id: ID!
+}
+
+enum Role {
+	ADMIN
+}
+
+type AdminStats {
+	count: Int
+}
+
+type Legacy @cached {
+	id: ID!
+}`
+
+	testCases := []struct {
+		Name  string
+		Allow []string
+		Errs  []string
+	}{
+		{
+			Name: "All",
+			Errs: []string{"cached: unused directive", "AdminStats: unused type", "Legacy: unused type"},
+		},
+		{
+			Name:  "Allowlist",
+			Allow: []string{"Admin*", "cached"},
+			Errs:  []string{"Legacy: unused type"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(src), 0)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			errs := CheckTypes(ToIR([]*ast.Document{doc}), UnusedChecker(testCase.Allow...))
+			if len(errs) != len(testCase.Errs) {
+				subT.Fatalf("expected errors: %v but got: %v", testCase.Errs, errs)
+			}
+
+			for i, err := range errs {
+				if SeverityOf(err) != SeverityWarning {
+					subT.Errorf("expected warning but got: %s", SeverityOf(err))
+				}
+				if !strings.HasSuffix(err.Error(), testCase.Errs[i]) {
+					subT.Errorf("expected error: %s but got: %s", testCase.Errs[i], err)
+				}
+			}
+		})
+	}
+}
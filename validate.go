@@ -1,9 +1,9 @@
 package compiler
 
 import (
+	"context"
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/gqlc/graphql/ast"
 	"github.com/gqlc/graphql/token"
@@ -17,6 +17,48 @@ var Types []*ast.TypeDecl
 // RegisterTypes registers pre-defined types with the compiler.
 func RegisterTypes(decls ...*ast.TypeDecl) { Types = append(Types, decls...) }
 
+// RegisterRepeatable marks the named directives as repeatable with the
+// global registry, allowing them to be applied more than once per location.
+//
+// The repeatable keyword on directive definitions isn't supported by
+// the parser yet, so directives must be registered with the compiler
+// instead.
+//
+func RegisterRepeatable(names ...string) { global.RegisterRepeatable(names...) }
+
+// IsRepeatable reports whether the named directive is repeatable with the
+// global registry.
+//
+func IsRepeatable(name string) bool { return global.IsRepeatable(name) }
+
+// Severity represents the severity of a type error.
+type Severity uint8
+
+const (
+	// SeverityError is the default severity, and should fail a build.
+	SeverityError Severity = iota
+
+	// SeverityWarning should be reported, but is up to the caller
+	// whether or not it fails a build.
+	SeverityWarning
+
+	// SeverityInfo is purely informational.
+	SeverityInfo
+)
+
+var severities = [...]string{
+	SeverityError:   "error",
+	SeverityWarning: "warning",
+	SeverityInfo:    "info",
+}
+
+func (s Severity) String() string {
+	if int(s) < len(severities) {
+		return severities[s]
+	}
+	return fmt.Sprintf("Severity(%d)", s)
+}
+
 // TypeError represents a type error.
 type TypeError struct {
 	// Document where type error was discovered
@@ -24,11 +66,58 @@ type TypeError struct {
 
 	// Type error message
 	Msg string
+
+	// Severity of the type error
+	Severity Severity
+
+	// Underlying error, if any
+	Err error
 }
 
 // Error returns a string representation of a TypeError.
 func (e *TypeError) Error() string {
-	return fmt.Sprintf("compiler: encountered type error in %s:%s", e.Doc.Name, e.Msg)
+	return wrapMsg(fmt.Sprintf("compiler: encountered type %s in %s:%s", e.Severity, e.Doc.Name, e.Msg), e.Err)
+}
+
+// Code returns the Diagnostic code of a TypeError: "type".
+func (e *TypeError) Code() string { return "type" }
+
+// Level returns the severity of the TypeError.
+func (e *TypeError) Level() Severity { return e.Severity }
+
+// Position returns the Document the TypeError was discovered in.
+func (e *TypeError) Position() Position { return Position{Doc: e.Doc.Name} }
+
+// Unwrap returns the underlying error, if any.
+func (e *TypeError) Unwrap() error { return e.Err }
+
+// SeverityOf returns the severity of an error. Errors can report their
+// severity by implementing: Level() Severity. All other errors are
+// considered to be of SeverityError.
+//
+func SeverityOf(err error) Severity {
+	l, ok := err.(interface{ Level() Severity })
+	if !ok {
+		return SeverityError
+	}
+	return l.Level()
+}
+
+// Failed reports whether any of the errors should fail a build.
+// Warnings only fail a build when warningsAsErrors is set.
+//
+func Failed(errs []error, warningsAsErrors bool) bool {
+	for _, err := range errs {
+		switch SeverityOf(err) {
+		case SeverityError:
+			return true
+		case SeverityWarning:
+			if warningsAsErrors {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // TypeChecker represents type checking functionality for a GraphQL Document.
@@ -37,6 +126,28 @@ type TypeChecker interface {
 	Check(ir IR) []error
 }
 
+// LimitedTypeChecker represents a TypeChecker which can stop
+// checking once it has found a given number of errors.
+//
+type LimitedTypeChecker interface {
+	TypeChecker
+
+	// CheckN performs type checking, stopping after n errors have
+	// been found. If n <= 0, it behaves the same as Check.
+	CheckN(ir IR, n int) []error
+}
+
+// ContextTypeChecker represents a TypeChecker which can stop checking
+// once its context is done, e.g. when a build is cancelled.
+//
+type ContextTypeChecker interface {
+	TypeChecker
+
+	// CheckContext performs type checking, stopping after n errors
+	// have been found, if n > 0, or once ctx is done.
+	CheckContext(ctx context.Context, ir IR, n int) []error
+}
+
 // TypeCheckerFn represents a single function behaving as a TypeChecker.
 type TypeCheckerFn func(IR) []error
 
@@ -47,23 +158,91 @@ func (f TypeCheckerFn) Check(ir IR) []error {
 
 var builtins = &ast.Document{Name: "gqlc.compiler.types"}
 
+// IsBuiltins reports whether the Document is the one CheckTypes uses
+// to provide the types given to RegisterTypes. TypeCheckers can use
+// it to skip registered types.
+//
+func IsBuiltins(doc *ast.Document) bool { return doc == builtins }
+
 // CheckTypes is a helper function for running a suite of
 // type checking on several GraphQL Documents. Any types given
 // to RegisterTypes will included as their very own document.
 //
-func CheckTypes(docs IR, checkers ...TypeChecker) (errs []error) {
-	docs[builtins] = toDeclMap(Types)
+// Errors are returned in a stable order: grouped by checker, in the
+// order they're given. The checkers provided by this module report
+// their errors by Document name, then by the position of each type.
+//
+func CheckTypes(docs IR, checkers ...TypeChecker) []error {
+	return CheckTypesN(docs, 0, checkers...)
+}
+
+// CheckTypesN is the same as CheckTypes, but stops type checking once
+// n errors have been found. If n <= 0, all errors are returned. Use a
+// n of 1 to fail fast on the first error.
+//
+// Checkers which implement LimitedTypeChecker are only asked for as
+// many errors as remain, so they can stop early too.
+//
+func CheckTypesN(docs IR, n int, checkers ...TypeChecker) []error {
+	errs, _ := CheckTypesContext(context.Background(), docs, n, checkers...)
+	return errs
+}
+
+// CheckTypesContext is the same as CheckTypesN, but stops type checking
+// once ctx is done, returning the errors found so far along with the
+// context's error. Checkers which implement ContextTypeChecker are given
+// ctx, so they can stop promptly; others are allowed to finish.
+//
+// The check stage, and a stage for each checker, are reported to any
+// Reporter carried by ctx. Checkers are named by their String method,
+// if they have one, and otherwise by their position, e.g. "check:1".
+//
+// The types of any Registry carried by ctx are used in place of Types.
+//
+func CheckTypesContext(ctx context.Context, docs IR, n int, checkers ...TypeChecker) (errs []error, err error) {
+	defer Stage(ctx, "check")()
+
+	docs[builtins] = toDeclMap(RegistryFrom(ctx).Types())
+	defer delete(docs, builtins)
+
+	for i, checker := range checkers {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		stage := fmt.Sprintf("check:%d", i)
+		if s, ok := checker.(fmt.Stringer); ok {
+			stage = "check:" + s.String()
+		}
+		done := Stage(ctx, stage)
+
+		rem := 0
+		if n > 0 {
+			rem = n - len(errs)
+		}
+
+		var cerrs []error
+		switch c := checker.(type) {
+		case ContextTypeChecker:
+			cerrs = c.CheckContext(ctx, docs, rem)
+		case LimitedTypeChecker:
+			cerrs = c.CheckN(docs, rem)
+		default:
+			cerrs = c.Check(docs)
+		}
+		done()
 
-	for _, checker := range checkers {
-		cerrs := checker.Check(docs)
 		if cerrs == nil {
 			continue
 		}
 
 		errs = append(errs, cerrs...)
+		if n > 0 && len(errs) >= n {
+			return errs[:n], nil
+		}
 	}
-	delete(docs, builtins)
 
+	err = ctx.Err()
 	return
 }
 
@@ -126,16 +305,22 @@ func init() {
 					List: []*ast.InputValue{
 						{
 							Name: &ast.Ident{Name: "paths"},
-							Type: &ast.InputValue_NonNull{
-								NonNull: &ast.NonNull{Type: &ast.NonNull_List{
-									List: &ast.List{Type: &ast.List_NonNull{
-										NonNull: &ast.NonNull{Type: &ast.NonNull_Ident{
-											Ident: &ast.Ident{Name: "String"},
-										}},
+							Type: &ast.InputValue_List{
+								List: &ast.List{Type: &ast.List_NonNull{
+									NonNull: &ast.NonNull{Type: &ast.NonNull_Ident{
+										Ident: &ast.Ident{Name: "String"},
 									}},
 								}},
 							},
 						},
+						{
+							Name: &ast.Ident{Name: "path"},
+							Type: &ast.InputValue_Ident{Ident: &ast.Ident{Name: "String"}},
+						},
+						{
+							Name: &ast.Ident{Name: "version"},
+							Type: &ast.InputValue_Ident{Ident: &ast.Ident{Name: "String"}},
+						},
 					},
 				},
 			}},
@@ -143,24 +328,29 @@ func init() {
 	})
 }
 
-// ImportValidator validates that all types are correctly imported.
+// ImportValidator validates that all types are correctly imported. Types
+// which are defined, but not imported, are reported along with the
+// Document which defines them and the @import to add.
+//
 var ImportValidator = TypeCheckerFn(validateImports)
 
 func validateImports(docs IR) (errs []error) {
 	imports := getImports(docs)
+	index := NewIndexSnapshot(docs)
 
-	for doc, mdecls := range docs {
+	for _, doc := range docs.Documents() {
 		if doc == builtins {
 			continue
 		}
 
+		mdecls := docs[doc]
 		dimports := imports[doc]
 
-		for _, decls := range mdecls {
-			rtypes := getUnknownTypes(decls, mdecls)
+		for _, name := range TypeNames(mdecls) {
+			rtypes := getUnknownTypes(mdecls[name], mdecls)
 
 			for _, rtype := range rtypes {
-				d, _ := Lookup(rtype, docs)
+				d, _ := index.Lookup(rtype)
 				if d == nil {
 					errs = append(errs, &TypeError{
 						Doc: doc,
@@ -172,7 +362,7 @@ func validateImports(docs IR) (errs []error) {
 				if _, ok := dimports[d]; !ok && d != builtins {
 					errs = append(errs, &TypeError{
 						Doc: doc,
-						Msg: fmt.Sprintf("unimported type: %s", rtype),
+						Msg: fmt.Sprintf("unimported type: %s: defined in %s, add: @import(paths: [%q])", rtype, d.Name, d.Name),
 					})
 				}
 			}
@@ -181,48 +371,106 @@ func validateImports(docs IR) (errs []error) {
 	return
 }
 
-func getImports(docs IR) map[*ast.Document]map[*ast.Document]struct{} {
-	imports := make(map[*ast.Document]map[*ast.Document]struct{}, len(docs))
-	docMap := make(map[string]*ast.Document, len(docs))
+// UnusedImportValidator reports, as warnings, the imports of a Document
+// which it doesn't reference any types, or directives, of. Since imports
+// aren't transitive, importing a Document only for its own imports is
+// unnecessary as well.
+//
+var UnusedImportValidator = TypeCheckerFn(validateUnusedImports)
 
+func validateUnusedImports(docs IR) (errs []error) {
+	docMap := make(map[string]*ast.Document, len(docs))
 	for doc := range docs {
 		docMap[doc.Name] = doc
 	}
 
-	paths := make([]*ast.BasicLit, 0, len(docs)-1)
-	for doc := range docs {
-		imports[doc] = nil
+	for _, doc := range docs.Documents() {
+		imps := DocImports(doc)
+		if doc == builtins || len(imps) == 0 {
+			continue
+		}
 
-		for _, dir := range doc.Directives {
-			if dir.Name != "import" {
-				continue
+		// Collect every name the Document refers to, including
+		// the types it extends but doesn't define.
+		mdecls := docs[doc]
+		refs := make(map[string]bool)
+		for _, d := range doc.Directives {
+			refs[d.Name] = true
+		}
+		for name, decls := range mdecls {
+			for _, decl := range decls {
+				ts := TypeSpecOf(decl)
+				walkRefs(ts, func(_ string, id *ast.Ident) { refs[id.Name] = true })
+				walkDirectives(ts, func(_ string, d *ast.DirectiveLit) { refs[d.Name] = true })
+			}
+			if !isDefined(decls) {
+				refs[name] = true
 			}
+		}
 
-			imps := dir.Args.Args[0]
-			compList := imps.Value.(*ast.Arg_CompositeLit).CompositeLit.Value.(*ast.CompositeLit_ListLit)
+		for _, imp := range imps {
+			idoc, ok := docMap[imp.Path]
+			if !ok {
+				continue
+			}
 
-			switch v := compList.ListLit.List.(type) {
-			case *ast.ListLit_BasicList:
-				paths = append(paths, v.BasicList.Values...)
-			case *ast.ListLit_CompositeList:
-				cpaths := v.CompositeList.Values
-				for _, c := range cpaths {
-					paths = append(paths, c.Value.(*ast.CompositeLit_BasicLit).BasicLit)
+			used := false
+			for name := range docs[idoc] {
+				if _, local := mdecls[name]; refs[name] && (!local || !isDefined(mdecls[name])) {
+					used = true
+					break
 				}
 			}
+			if used {
+				continue
+			}
 
-			dimports := make(map[*ast.Document]struct{}, len(paths))
-			dimports[builtins] = struct{}{}
+			errs = append(errs, &TypeError{
+				Doc:      doc,
+				Msg:      fmt.Sprintf("unused import: %s", imp.Path),
+				Severity: SeverityWarning,
+			})
+		}
+	}
+	return
+}
 
-			for _, path := range paths {
-				p := strings.Trim(path.Value, "\"")
-				dimports[docMap[p]] = struct{}{}
-			}
+// isDefined reports whether any of the declarations is a type definition,
+// rather than an extension.
+//
+func isDefined(decls []*ast.TypeDecl) bool {
+	for _, decl := range decls {
+		if _, ok := decl.Spec.(*ast.TypeDecl_TypeSpec); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func getImports(docs IR) map[*ast.Document]map[*ast.Document]struct{} {
+	imports := make(map[*ast.Document]map[*ast.Document]struct{}, len(docs))
+	docMap := make(map[string]*ast.Document, len(docs))
+
+	for doc := range docs {
+		docMap[doc.Name] = doc
+	}
+
+	for doc := range docs {
+		imports[doc] = nil
+
+		imps := DocImports(doc)
+		if len(imps) == 0 {
+			continue
+		}
+
+		dimports := make(map[*ast.Document]struct{}, len(imps)+1)
+		dimports[builtins] = struct{}{}
 
-			imports[doc] = dimports
+		for _, imp := range imps {
+			dimports[docMap[imp.Path]] = struct{}{}
 		}
 
-		paths = paths[:0]
+		imports[doc] = dimports
 	}
 
 	return imports
@@ -1,10 +1,13 @@
 package compiler
 
 import (
+	"context"
+	"errors"
 	"io"
 	"strings"
 	"testing"
 
+	"github.com/gqlc/graphql/ast"
 	"github.com/gqlc/graphql/parser"
 	"github.com/gqlc/graphql/token"
 )
@@ -33,7 +36,7 @@ type Msg {
 				"b": strings.NewReader(`@import(paths: ["c"])`),
 				"c": strings.NewReader("scalar Time"),
 			},
-			Err: "compiler: encountered type error in a:unimported type: Time",
+			Err: `compiler: encountered type error in a:unimported type: Time: defined in c, add: @import(paths: ["c"])`,
 		},
 	}
 
@@ -61,3 +64,196 @@ type Msg {
 		})
 	}
 }
+
+func TestFailed(t *testing.T) {
+	doc := &ast.Document{Name: "a"}
+	warning := &TypeError{Doc: doc, Msg: "deprecated", Severity: SeverityWarning}
+	info := &TypeError{Doc: doc, Msg: "note", Severity: SeverityInfo}
+
+	testCases := []struct {
+		Name             string
+		Errs             []error
+		WarningsAsErrors bool
+		Failed           bool
+	}{
+		{
+			Name: "None",
+		},
+		{
+			Name:   "Error",
+			Errs:   []error{info, &TypeError{Doc: doc, Msg: "undefined type: A"}},
+			Failed: true,
+		},
+		{
+			Name:   "PlainError",
+			Errs:   []error{errors.New("unknown")},
+			Failed: true,
+		},
+		{
+			Name: "Warning",
+			Errs: []error{info, warning},
+		},
+		{
+			Name:             "WarningAsError",
+			Errs:             []error{info, warning},
+			WarningsAsErrors: true,
+			Failed:           true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			if Failed(testCase.Errs, testCase.WarningsAsErrors) != testCase.Failed {
+				subT.Fail()
+			}
+		})
+	}
+
+	if warning.Error() != "compiler: encountered type warning in a:deprecated" {
+		t.Errorf("unexpected warning string: %s", warning)
+	}
+}
+
+type limitedChecker struct{ n int }
+
+func (c *limitedChecker) Check(ir IR) []error { return c.CheckN(ir, 0) }
+
+func (c *limitedChecker) CheckN(ir IR, n int) (errs []error) {
+	c.n = n
+	for i := 0; i < 5 && (n <= 0 || i < n); i++ {
+		errs = append(errs, errors.New("limited"))
+	}
+	return
+}
+
+func TestCheckTypesN(t *testing.T) {
+	three := TypeCheckerFn(func(IR) []error {
+		return []error{errors.New("a"), errors.New("b"), errors.New("c")}
+	})
+
+	testCases := []struct {
+		Name string
+		N    int
+		Len  int
+		Rem  int
+	}{
+		{Name: "Unlimited", N: 0, Len: 8, Rem: 0},
+		{Name: "FailFast", N: 1, Len: 1},
+		{Name: "WithinFirst", N: 2, Len: 2},
+		{Name: "WithinSecond", N: 5, Len: 5, Rem: 2},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			limited := &limitedChecker{n: -1}
+
+			errs := CheckTypesN(make(IR), testCase.N, three, limited)
+			if len(errs) != testCase.Len {
+				subT.Errorf("expected %d errors but got: %d", testCase.Len, len(errs))
+			}
+
+			if testCase.Len > 3 && limited.n != testCase.Rem {
+				subT.Errorf("expected limited checker to be given: %d, but got: %d", testCase.Rem, limited.n)
+			}
+		})
+	}
+}
+
+func TestCheckTypesContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var ran []string
+	first := TypeCheckerFn(func(IR) []error {
+		ran = append(ran, "first")
+		cancel()
+		return []error{errors.New("a")}
+	})
+	second := TypeCheckerFn(func(IR) []error {
+		ran = append(ran, "second")
+		return nil
+	})
+
+	errs, err := CheckTypesContext(ctx, make(IR), 0, first, second)
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled but got: %v", err)
+	}
+	if len(errs) != 1 {
+		t.Errorf("expected errors found before cancellation but got: %v", errs)
+	}
+	if len(ran) != 1 {
+		t.Errorf("expected only the first checker to run but got: %v", ran)
+	}
+}
+
+func TestUnusedImportValidator(t *testing.T) {
+	testCases := []struct {
+		Name string
+		Docs map[string]string
+		Errs []string
+	}{
+		{
+			Name: "Used",
+			Docs: map[string]string{
+				"a": `@import(paths: ["b", "c", "d", "e"])
+type Query @auth {
+	now: Time
+}
+
+extend type User {
+	name: String
+}
+
+input Filter {
+	role: Role
+}`,
+				"b": "scalar Time",
+				"c": "directive @auth on OBJECT",
+				"d": "type User { id: ID }",
+				"e": "enum Role {\n\tADMIN\n}",
+			},
+		},
+		{
+			Name: "Unused",
+			Docs: map[string]string{
+				"a": `@import(paths: ["b", "c"])
+scalar Time
+
+type Query {
+	now: Time
+}`,
+				"b": "scalar Time",
+				"c": `@import(paths: ["b"])
+scalar Date`,
+			},
+			Errs: []string{
+				"compiler: encountered type warning in a:unused import: b",
+				"compiler: encountered type warning in a:unused import: c",
+				"compiler: encountered type warning in c:unused import: b",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			readers := make(map[string]io.Reader, len(testCase.Docs))
+			for name, src := range testCase.Docs {
+				readers[name] = strings.NewReader(src)
+			}
+
+			docs, err := parser.ParseDocs(token.NewDocSet(), readers, 0)
+			if err != nil {
+				subT.Fatal(err)
+			}
+
+			errs := CheckTypes(ToIR(docs), UnusedImportValidator)
+			if len(errs) != len(testCase.Errs) {
+				subT.Fatalf("expected errors: %v but got: %v", testCase.Errs, errs)
+			}
+			for i, err := range errs {
+				if err.Error() != testCase.Errs[i] {
+					subT.Errorf("expected error: %s but got: %s", testCase.Errs[i], err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package compiler
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/gqlc/graphql/ast"
+)
+
+// InternalDirective marks a Document, type, field, argument, enum value or
+// input field as internal, so it's removed by Public.
+//
+// directive @internal on DOCUMENT | SCALAR | OBJECT | FIELD_DEFINITION | ARGUMENT_DEFINITION | INTERFACE | UNION | ENUM | ENUM_VALUE | INPUT_OBJECT | INPUT_FIELD_DEFINITION
+//
+var InternalDirective = NewDirective("internal",
+	ast.DirectiveLocation_DOCUMENT,
+	ast.DirectiveLocation_SCALAR,
+	ast.DirectiveLocation_OBJECT,
+	ast.DirectiveLocation_FIELD_DEFINITION,
+	ast.DirectiveLocation_ARGUMENT_DEFINITION,
+	ast.DirectiveLocation_INTERFACE,
+	ast.DirectiveLocation_UNION,
+	ast.DirectiveLocation_ENUM,
+	ast.DirectiveLocation_ENUM_VALUE,
+	ast.DirectiveLocation_INPUT_OBJECT,
+	ast.DirectiveLocation_INPUT_FIELD_DEFINITION,
+).Decl()
+
+// PublicDirective keeps a type of an @internal Document public.
+//
+// directive @public on SCALAR | OBJECT | INTERFACE | UNION | ENUM | INPUT_OBJECT
+//
+var PublicDirective = NewDirective("public",
+	ast.DirectiveLocation_SCALAR,
+	ast.DirectiveLocation_OBJECT,
+	ast.DirectiveLocation_INTERFACE,
+	ast.DirectiveLocation_UNION,
+	ast.DirectiveLocation_ENUM,
+	ast.DirectiveLocation_INPUT_OBJECT,
+).Decl()
+
+// RegisterVisibility registers the @internal and @public directives with r,
+// so documents which apply them type check. Visibility is opt-in, since not
+// every schema has internal parts.
+//
+func RegisterVisibility(r *Registry) {
+	r.RegisterTypes(InternalDirective, PublicDirective)
+}
+
+// Public returns a copy of the IR with everything marked @internal removed,
+// so one source schema can be used to generate both internal and public
+// outputs. The given IR is left as is.
+//
+// Types, fields, arguments, enum values and input fields may be marked
+// @internal. Applying @internal to a Document marks all of its types as
+// internal, except for those marked @public.
+//
+// Any remaining references to internal types, e.g. a public field of an
+// internal type, are returned as TypeErrors.
+//
+func Public(ir IR) (IR, []error) {
+	internal := make(map[string]bool)
+	for doc, types := range ir {
+		docInternal := hasDirective(doc.Directives, "internal")
+
+		for name, decls := range types {
+			var isInternal, isPublic bool
+			for _, decl := range decls {
+				dirs := TypeSpecOf(decl).Directives
+				isInternal = isInternal || hasDirective(dirs, "internal")
+				isPublic = isPublic || hasDirective(dirs, "public")
+			}
+
+			if isInternal || (docInternal && !isPublic) {
+				internal[name] = true
+			}
+		}
+	}
+
+	var errs []error
+	pub := make(IR, len(ir))
+	for _, doc := range ir.Documents() {
+		if IsBuiltins(doc) {
+			continue
+		}
+
+		pdoc := proto.Clone(doc).(*ast.Document)
+		pdoc.Types = nil
+		pdoc.Directives = withoutVisibility(pdoc.Directives)
+
+		types := make(map[string][]*ast.TypeDecl, len(ir[doc]))
+		pub[pdoc] = types
+
+		for _, name := range TypeNames(ir[doc]) {
+			if internal[name] {
+				continue
+			}
+
+			for _, decl := range ir[doc][name] {
+				decl = proto.Clone(decl).(*ast.TypeDecl)
+				ts := TypeSpecOf(decl)
+				removeInternal(ts)
+
+				prefix := name
+				if ts.Name == nil {
+					prefix = "schema"
+				}
+				walkRefs(ts, func(field string, id *ast.Ident) {
+					if !internal[id.Name] {
+						return
+					}
+
+					path := prefix
+					if field != "" {
+						path += "." + field
+					}
+					errs = append(errs, &TypeError{
+						Doc: doc,
+						Msg: fmt.Sprintf("%s: references internal type: %s", path, id.Name),
+					})
+				})
+
+				types[name] = append(types[name], decl)
+			}
+		}
+	}
+
+	return pub, errs
+}
+
+// removeInternal removes the members of a TypeSpec marked @internal,
+// along with any visibility directives.
+//
+func removeInternal(ts *ast.TypeSpec) {
+	ts.Directives = withoutVisibility(ts.Directives)
+
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		removeInternalFields(v.Schema.RootOps)
+	case *ast.TypeSpec_Object:
+		removeInternalFields(v.Object.Fields)
+	case *ast.TypeSpec_Interface:
+		removeInternalFields(v.Interface.Fields)
+	case *ast.TypeSpec_Enum:
+		removeInternalFields(v.Enum.Values)
+	case *ast.TypeSpec_Input:
+		removeInternalArgs(v.Input.Fields)
+	case *ast.TypeSpec_Directive:
+		removeInternalArgs(v.Directive.Args)
+	}
+}
+
+func removeInternalFields(fields *ast.FieldList) {
+	if fields == nil {
+		return
+	}
+
+	l := fields.List[:0]
+	for _, f := range fields.List {
+		if hasDirective(f.Directives, "internal") {
+			continue
+		}
+
+		f.Directives = withoutVisibility(f.Directives)
+		removeInternalArgs(f.Args)
+		l = append(l, f)
+	}
+	fields.List = l
+}
+
+func removeInternalArgs(args *ast.InputValueList) {
+	if args == nil {
+		return
+	}
+
+	l := args.List[:0]
+	for _, a := range args.List {
+		if hasDirective(a.Directives, "internal") {
+			continue
+		}
+
+		a.Directives = withoutVisibility(a.Directives)
+		l = append(l, a)
+	}
+	args.List = l
+}
+
+func withoutVisibility(dirs []*ast.DirectiveLit) []*ast.DirectiveLit {
+	var l []*ast.DirectiveLit
+	for _, d := range dirs {
+		if d.Name != "internal" && d.Name != "public" {
+			l = append(l, d)
+		}
+	}
+	return l
+}
@@ -0,0 +1,156 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/parser"
+	"github.com/gqlc/graphql/token"
+)
+
+func TestPublic(t *testing.T) {
+	testCases := []struct {
+		Name   string
+		Src    string
+		Types  []string
+		Fields map[string][]string
+		Errs   []string
+	}{
+		{
+			Name: "Members",
+			Src: `type Query {
+	user(id: ID!, debug: Boolean @internal): User
+	metrics: String @internal
+}
+
+type User @public {
+	name: String
+}
+
+enum Role {
+	USER
+	ADMIN @internal
+}
+
+type Admin @internal {
+	secret: String
+}`,
+			Types: []string{"Query", "User", "Role"},
+			Fields: map[string][]string{
+				"Query": {"user"},
+				"Role":  {"USER"},
+			},
+		},
+		{
+			Name: "Document",
+			Src: `@internal
+
+type Query @public {
+	user: User
+}
+
+type User {
+	name: String
+}`,
+			Types: []string{"Query"},
+			Errs: []string{
+				"compiler: encountered type error in test:Query.user: references internal type: User",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			doc, err := parser.ParseDoc(token.NewDocSet(), "test", strings.NewReader(testCase.Src), 0)
+			if err != nil {
+				subT.Error(err)
+				return
+			}
+			ir := ToIR([]*ast.Document{doc})
+
+			pub, errs := Public(ir)
+			if len(errs) != len(testCase.Errs) {
+				subT.Errorf("expected errors: %v but got: %v", testCase.Errs, errs)
+				return
+			}
+			for i, err := range errs {
+				if err.Error() != testCase.Errs[i] {
+					subT.Errorf("expected error: %s but got: %s", testCase.Errs[i], err)
+				}
+			}
+
+			if len(pub) != 1 {
+				subT.Fatalf("expected one document but got: %d", len(pub))
+			}
+			pdoc := pub.Documents()[0]
+			if len(pdoc.Directives) != 0 {
+				subT.Errorf("expected visibility directives to be removed but got: %v", pdoc.Directives)
+			}
+
+			types := TypeNames(pub[pdoc])
+			if strings.Join(types, ",") != strings.Join(testCase.Types, ",") {
+				subT.Errorf("expected types: %v but got: %v", testCase.Types, types)
+			}
+
+			for name, expected := range testCase.Fields {
+				var fields []string
+				ts := TypeSpecOf(pub[pdoc][name][0])
+				if len(ts.Directives) != 0 {
+					subT.Errorf("expected visibility directives to be removed from: %s", name)
+				}
+
+				switch v := ts.Type.(type) {
+				case *ast.TypeSpec_Object:
+					for _, f := range v.Object.Fields.List {
+						fields = append(fields, f.Name.Name)
+						if f.Args != nil && len(f.Args.List) != 1 {
+							subT.Errorf("expected internal argument to be removed from: %s.%s", name, f.Name.Name)
+						}
+					}
+				case *ast.TypeSpec_Enum:
+					for _, f := range v.Enum.Values.List {
+						fields = append(fields, f.Name.Name)
+					}
+				}
+
+				if strings.Join(fields, ",") != strings.Join(expected, ",") {
+					subT.Errorf("expected fields of %s: %v but got: %v", name, expected, fields)
+				}
+			}
+
+			if len(ir[doc]) == len(pub[pdoc]) {
+				subT.Error("expected original IR to be left as is")
+			}
+		})
+	}
+}
+
+func TestRegisterVisibility(t *testing.T) {
+	r := NewRegistry(GlobalRegistry())
+	RegisterVisibility(r)
+
+	testCases := []struct {
+		Name       string
+		Registry   *Registry
+		Registered bool
+	}{
+		{Name: "Registry", Registry: r, Registered: true},
+		{Name: "Global", Registry: GlobalRegistry()},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(subT *testing.T) {
+			found := make(map[string]bool)
+			for _, decl := range testCase.Registry.Types() {
+				found[declName(decl)] = true
+			}
+
+			for _, name := range []string{"internal", "public"} {
+				if found[name] != testCase.Registered {
+					subT.Errorf("expected @%s to be registered: %v", name, testCase.Registered)
+				}
+			}
+		})
+	}
+}
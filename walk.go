@@ -0,0 +1,164 @@
+package compiler
+
+import (
+	"github.com/gqlc/graphql/ast"
+	"github.com/gqlc/graphql/token"
+)
+
+// TypeSpecOf returns the TypeSpec of a declaration or extension.
+func TypeSpecOf(decl *ast.TypeDecl) *ast.TypeSpec {
+	switch v := decl.Spec.(type) {
+	case *ast.TypeDecl_TypeSpec:
+		return v.TypeSpec
+	case *ast.TypeDecl_TypeExtSpec:
+		return v.TypeExtSpec.Type
+	}
+	return nil
+}
+
+// DeclTok returns the keyword token of a declaration or extension.
+func DeclTok(decl *ast.TypeDecl) token.Token {
+	if ext, ok := decl.Spec.(*ast.TypeDecl_TypeExtSpec); ok {
+		return ext.TypeExtSpec.Tok
+	}
+	return decl.Tok
+}
+
+// IsExtension reports whether a declaration is a type extension.
+func IsExtension(decl *ast.TypeDecl) bool {
+	_, ok := decl.Spec.(*ast.TypeDecl_TypeExtSpec)
+	return ok
+}
+
+// walkRefs calls f for every type reference in a TypeSpec.
+// The field is the name of the field, argument, or member
+// which holds the reference; it is empty for interfaces and
+// union members.
+//
+func walkRefs(ts *ast.TypeSpec, f func(field string, id *ast.Ident)) {
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		walkFieldRefs(v.Schema.RootOps, f)
+	case *ast.TypeSpec_Object:
+		for _, i := range v.Object.Interfaces {
+			f("", i)
+		}
+
+		walkFieldRefs(v.Object.Fields, f)
+	case *ast.TypeSpec_Interface:
+		walkFieldRefs(v.Interface.Fields, f)
+	case *ast.TypeSpec_Union:
+		for _, m := range v.Union.Members {
+			f("", m)
+		}
+	case *ast.TypeSpec_Input:
+		walkArgRefs("", v.Input.Fields, f)
+	case *ast.TypeSpec_Directive:
+		walkArgRefs("", v.Directive.Args, f)
+	}
+}
+
+func walkFieldRefs(fields *ast.FieldList, f func(string, *ast.Ident)) {
+	if fields == nil {
+		return
+	}
+
+	for _, field := range fields.List {
+		walkArgRefs(field.Name.Name, field.Args, f)
+
+		var id *ast.Ident
+		switch x := field.Type.(type) {
+		case *ast.Field_Ident:
+			id = x.Ident
+		case *ast.Field_List:
+			id = unwrapType(x.List)
+		case *ast.Field_NonNull:
+			id = unwrapType(x.NonNull)
+		}
+		if id == nil {
+			continue
+		}
+
+		f(field.Name.Name, id)
+	}
+}
+
+func walkArgRefs(host string, args *ast.InputValueList, f func(string, *ast.Ident)) {
+	if args == nil {
+		return
+	}
+
+	for _, arg := range args.List {
+		var id *ast.Ident
+		switch x := arg.Type.(type) {
+		case *ast.InputValue_Ident:
+			id = x.Ident
+		case *ast.InputValue_List:
+			id = unwrapType(x.List)
+		case *ast.InputValue_NonNull:
+			id = unwrapType(x.NonNull)
+		}
+		if id == nil {
+			continue
+		}
+
+		name := arg.Name.Name
+		if host != "" {
+			name = host + "." + name
+		}
+		f(name, id)
+	}
+}
+
+// walkDirectives calls f for every directive applied in a TypeSpec,
+// including those applied to its fields, arguments, and enum values.
+// The field is named in the same manner as walkRefs.
+//
+func walkDirectives(ts *ast.TypeSpec, f func(field string, d *ast.DirectiveLit)) {
+	for _, d := range ts.Directives {
+		f("", d)
+	}
+
+	var fields *ast.FieldList
+	var args *ast.InputValueList
+	switch v := ts.Type.(type) {
+	case *ast.TypeSpec_Schema:
+		fields = v.Schema.RootOps
+	case *ast.TypeSpec_Object:
+		fields = v.Object.Fields
+	case *ast.TypeSpec_Interface:
+		fields = v.Interface.Fields
+	case *ast.TypeSpec_Enum:
+		fields = v.Enum.Values
+	case *ast.TypeSpec_Input:
+		args = v.Input.Fields
+	case *ast.TypeSpec_Directive:
+		args = v.Directive.Args
+	}
+
+	if fields != nil {
+		for _, field := range fields.List {
+			for _, d := range field.Directives {
+				f(field.Name.Name, d)
+			}
+
+			if field.Args == nil {
+				continue
+			}
+
+			for _, arg := range field.Args.List {
+				for _, d := range arg.Directives {
+					f(field.Name.Name+"."+arg.Name.Name, d)
+				}
+			}
+		}
+	}
+
+	if args != nil {
+		for _, arg := range args.List {
+			for _, d := range arg.Directives {
+				f(arg.Name.Name, d)
+			}
+		}
+	}
+}